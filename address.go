@@ -0,0 +1,115 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// AddressLength is the length of a Koinos address in bytes: a version byte,
+// a 20 byte public key hash and a 4 byte checksum
+const AddressLength = 1 + 20 + 4
+
+// Address is a Koinos address in its byte form, as returned by KoinosKey.AddressBytes
+type Address []byte
+
+// NewAddress creates an address from the given bytes, validating the checksum
+func NewAddress(b []byte) (Address, error) {
+	address := Address(b)
+	if err := address.Validate(); err != nil {
+		return nil, err
+	}
+
+	return address, nil
+}
+
+// ParseAddress parses a base58 encoded address, validating the checksum
+func ParseAddress(s string) (Address, error) {
+	decoded := base58.Decode(s)
+	if len(decoded) == 0 {
+		return nil, ErrInvalidAddress
+	}
+
+	return NewAddress(decoded)
+}
+
+// Validate checks the length, checksum and version of the address
+func (a Address) Validate() error {
+	if len(a) != AddressLength {
+		return ErrInvalidAddress
+	}
+
+	cksum := chainhash.DoubleHashB(a[:AddressLength-4])[:4]
+	if !bytes.Equal(cksum, a[AddressLength-4:]) {
+		return ErrAddressChecksumMismatch
+	}
+
+	if a[0] != chaincfg.MainNetParams.PubKeyHashAddrID {
+		return fmt.Errorf("%w: version %d", ErrInvalidAddress, a[0])
+	}
+
+	return nil
+}
+
+// Version returns the version byte of the address
+func (a Address) Version() byte {
+	if len(a) == 0 {
+		return 0
+	}
+
+	return a[0]
+}
+
+// Bytes returns the address bytes
+func (a Address) Bytes() []byte {
+	return []byte(a)
+}
+
+// Equal returns true if both addresses are the same
+func (a Address) Equal(other Address) bool {
+	return bytes.Equal(a, other)
+}
+
+// String returns the base58 encoded address
+func (a Address) String() string {
+	return base58.Encode(a)
+}
+
+// MarshalText encodes the address as base58 text. An empty address is encoded as empty text.
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText decodes a base58 address, validating the checksum. Empty text decodes to an empty address.
+func (a *Address) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*a = nil
+		return nil
+	}
+
+	address, err := ParseAddress(string(text))
+	if err != nil {
+		return err
+	}
+
+	*a = address
+	return nil
+}
+
+// MarshalYAML encodes the address as a base58 yaml string
+func (a Address) MarshalYAML() (interface{}, error) {
+	return a.String(), nil
+}
+
+// UnmarshalYAML decodes a base58 yaml string, validating the checksum. An empty string decodes to an empty address.
+func (a *Address) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	return a.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,86 @@
+package util
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestAddress(t *testing.T) {
+	s := "13Sqw4TrwdZ8RZ9UVfqqA2i3mrbeumcWba"
+
+	address, err := ParseAddress(s)
+	assert.NoError(t, err)
+	assert.Equal(t, s, address.String())
+	assert.Equal(t, byte(0x00), address.Version())
+	assert.True(t, CheckIsValidAddress(s))
+
+	key, err := GenerateKoinosKey()
+	assert.NoError(t, err)
+	address, err = NewAddress(key.AddressBytes())
+	assert.NoError(t, err)
+	assert.Equal(t, key.AddressBytes(), address.Bytes())
+
+	// Typo in the last character
+	_, err = ParseAddress("13Sqw4TrwdZ8RZ9UVfqqA2i3mrbeumcWbb")
+	assert.ErrorIs(t, err, ErrAddressChecksumMismatch)
+	assert.False(t, CheckIsValidAddress("13Sqw4TrwdZ8RZ9UVfqqA2i3mrbeumcWbb"))
+
+	_, err = ParseAddress("13Sqw4TrwdZ8RZ9UV")
+	assert.ErrorIs(t, err, ErrInvalidAddress)
+
+	_, err = ParseAddress("0OIl")
+	assert.ErrorIs(t, err, ErrInvalidAddress)
+
+	// Valid base58check, but a pay to script hash version
+	_, err = ParseAddress("3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy")
+	assert.ErrorIs(t, err, ErrInvalidAddress)
+	assert.False(t, CheckIsValidAddress("3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy"))
+}
+
+func TestAddressMarshalling(t *testing.T) {
+	type config struct {
+		Address Address `json:"address" yaml:"address"`
+	}
+
+	address, err := ParseAddress("13Sqw4TrwdZ8RZ9UVfqqA2i3mrbeumcWba")
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(config{Address: address})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"address":"13Sqw4TrwdZ8RZ9UVfqqA2i3mrbeumcWba"}`, string(data))
+
+	var c config
+	assert.NoError(t, json.Unmarshal(data, &c))
+	assert.True(t, address.Equal(c.Address))
+
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"address":"13Sqw4TrwdZ8RZ9UVfqqA2i3mrbeumcWbb"}`), &c), ErrAddressChecksumMismatch)
+
+	data, err = yaml.Marshal(config{Address: address})
+	assert.NoError(t, err)
+	assert.Equal(t, "address: 13Sqw4TrwdZ8RZ9UVfqqA2i3mrbeumcWba\n", string(data))
+
+	c = config{}
+	assert.NoError(t, yaml.Unmarshal(data, &c))
+	assert.True(t, address.Equal(c.Address))
+
+	assert.ErrorIs(t, yaml.Unmarshal([]byte("address: 13Sqw4TrwdZ8RZ9UVfqqA2i3mrbeumcWbb\n"), &c), ErrAddressChecksumMismatch)
+
+	// An empty address survives a round trip
+	data, err = json.Marshal(config{})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"address":""}`, string(data))
+
+	c = config{Address: address}
+	assert.NoError(t, json.Unmarshal(data, &c))
+	assert.Empty(t, c.Address)
+
+	data, err = yaml.Marshal(config{})
+	assert.NoError(t, err)
+
+	c = config{Address: address}
+	assert.NoError(t, yaml.Unmarshal(data, &c))
+	assert.Empty(t, c.Address)
+}
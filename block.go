@@ -0,0 +1,165 @@
+package util
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/canonical"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	"github.com/multiformats/go-multihash"
+	"google.golang.org/protobuf/proto"
+)
+
+// CalculateBlockID calculates the multihash ID of the given block header
+func CalculateBlockID(header *protocol.BlockHeader) ([]byte, error) {
+	return HashMessage(header)
+}
+
+// BuildBlock creates a block from the given header and transactions. The transaction merkle root
+// and block ID are calculated and the block is signed with the given signer. If the header has no
+// signer, the signer's address is used. The given header is not modified.
+func BuildBlock(header *protocol.BlockHeader, transactions []*protocol.Transaction, signer Signer) (*protocol.Block, error) {
+	if header == nil {
+		return nil, ErrMissingHeader
+	}
+
+	header = proto.Clone(header).(*protocol.BlockHeader)
+
+	merkleRoot, err := CalculateTransactionMerkleRoot(transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	header.TransactionMerkleRoot = merkleRoot
+	if len(header.Signer) == 0 {
+		header.Signer = signer.Address()
+	}
+
+	id, err := CalculateBlockID(header)
+	if err != nil {
+		return nil, err
+	}
+
+	block := &protocol.Block{Id: id, Header: header, Transactions: transactions}
+
+	// Sign the block
+	if err := SignBlockWithSigner(signer, block); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// CalculateTransactionMerkleLeaves calculates the merkle leaves of a transaction as it is included
+// in the transaction merkle root: the hash of the canonical header, then the hash of the
+// concatenated signatures
+func CalculateTransactionMerkleLeaves(tx *protocol.Transaction) ([][]byte, error) {
+	headerBytes, err := canonical.Marshal(tx.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	headerHash := sha256.Sum256(headerBytes)
+	headerLeaf, err := multihash.Encode(headerHash[:], multihash.SHA2_256)
+	if err != nil {
+		return nil, err
+	}
+
+	hasher := sha256.New()
+	for _, signature := range tx.Signatures {
+		hasher.Write(signature)
+	}
+
+	signaturesLeaf, err := multihash.Encode(hasher.Sum(nil), multihash.SHA2_256)
+	if err != nil {
+		return nil, err
+	}
+
+	return [][]byte{headerLeaf, signaturesLeaf}, nil
+}
+
+// CalculateTransactionMerkleRoot calculates the merkle root of the given transactions
+func CalculateTransactionMerkleRoot(transactions []*protocol.Transaction) ([]byte, error) {
+	leaves := make([][]byte, 0, len(transactions)*2)
+	for _, tx := range transactions {
+		txLeaves, err := CalculateTransactionMerkleLeaves(tx)
+		if err != nil {
+			return nil, err
+		}
+
+		leaves = append(leaves, txLeaves...)
+	}
+
+	return CalculateMerkleRoot(leaves)
+}
+
+// ValidateBlock checks that the block ID and transaction merkle root match the block contents
+func ValidateBlock(block *protocol.Block) error {
+	if block.Header == nil {
+		return ErrMissingHeader
+	}
+
+	merkleRoot, err := CalculateTransactionMerkleRoot(block.Transactions)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(merkleRoot, block.Header.TransactionMerkleRoot) {
+		return ErrTransactionMerkleRootMismatch
+	}
+
+	id, err := CalculateBlockID(block.Header)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(id, block.Id) {
+		return ErrBlockIDMismatch
+	}
+
+	return nil
+}
+
+// RecoverBlockSigner recovers the address of the key that signed the block
+func RecoverBlockSigner(block *protocol.Block) ([]byte, error) {
+	if block.Header == nil {
+		return nil, ErrMissingHeader
+	}
+
+	// Recompute the ID so that the signature is checked against the actual header contents
+	id, err := CalculateBlockID(block.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(id, block.Id) {
+		return nil, ErrBlockIDMismatch
+	}
+
+	idBytes, err := multihash.Decode(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return RecoverAddress(idBytes.Digest, block.Signature)
+}
+
+// VerifyBlockSignature verifies that the block was signed by the given address.
+// If no address is given, the block header signer is used.
+func VerifyBlockSignature(block *protocol.Block, address []byte) error {
+	signer, err := RecoverBlockSigner(block)
+	if err != nil {
+		return err
+	}
+
+	if address == nil {
+		address = block.Header.Signer
+	}
+
+	if !bytes.Equal(signer, address) {
+		return fmt.Errorf("%w: %s", ErrMissingSignature, DisplayAddress(address))
+	}
+
+	return nil
+}
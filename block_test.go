@@ -0,0 +1,155 @@
+package util
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeTestBlock(t *testing.T, key *KoinosKey) *protocol.Block {
+	builder := TransactionBuilder{Operations: makeTestOperations(), ChainID: makeTestChainID(), Nonce: 1, RCLimit: 100, Payer: key.AddressBytes()}
+	transaction, err := builder.BuildAndSign(key)
+	assert.NoError(t, err)
+
+	header := &protocol.BlockHeader{
+		Previous:  makeTestChainID(),
+		Height:    10,
+		Timestamp: 1000,
+	}
+
+	block, err := BuildBlock(header, []*protocol.Transaction{transaction}, key)
+	assert.NoError(t, err)
+
+	return block
+}
+
+func TestBuildBlock(t *testing.T) {
+	key, err := GenerateKoinosKey()
+	assert.NoError(t, err)
+
+	header := &protocol.BlockHeader{Height: 1, Timestamp: 1000}
+	block, err := BuildBlock(header, nil, key)
+	assert.NoError(t, err)
+	assert.Empty(t, header.Signer)
+	assert.Empty(t, header.TransactionMerkleRoot)
+
+	_, err = BuildBlock(nil, nil, key)
+	assert.ErrorIs(t, err, ErrMissingHeader)
+
+	block = makeTestBlock(t, key)
+	assert.Equal(t, key.AddressBytes(), block.Header.Signer)
+	assert.Len(t, block.Signature, 65)
+
+	merkleRoot, err := CalculateTransactionMerkleRoot(block.Transactions)
+	assert.NoError(t, err)
+	assert.Equal(t, merkleRoot, block.Header.TransactionMerkleRoot)
+
+	signer, err := RecoverBlockSigner(block)
+	assert.NoError(t, err)
+	assert.Equal(t, key.AddressBytes(), signer)
+
+	// Re-signing replaces the signature
+	otherKey, err := GenerateKoinosKey()
+	assert.NoError(t, err)
+	assert.NoError(t, SignBlock(otherKey.PrivateBytes(), block))
+	signer, err = RecoverBlockSigner(block)
+	assert.NoError(t, err)
+	assert.Equal(t, otherKey.AddressBytes(), signer)
+}
+
+func TestTransactionMerkleRoot(t *testing.T) {
+	hashBytes := func(data string) []byte {
+		sum := sha256.Sum256([]byte(data))
+		mh, _ := multihash.Encode(sum[:], multihash.SHA2_256)
+		return mh
+	}
+
+	payer := make([]byte, 25)
+	for i := range payer {
+		payer[i] = byte(i)
+	}
+
+	makeTransaction := func(nonce byte, signatures ...[]byte) *protocol.Transaction {
+		header := &protocol.TransactionHeader{
+			ChainId:             hashBytes("chain"),
+			RcLimit:             100,
+			Nonce:               []byte{0x10, nonce},
+			OperationMerkleRoot: hashBytes("operations"),
+			Payer:               payer,
+		}
+		return &protocol.Transaction{Header: header, Signatures: signatures}
+	}
+
+	signatureA := make([]byte, 65)
+	signatureB := make([]byte, 65)
+	signatureA[0], signatureB[0] = 0x1f, 0x20
+	for i := 0; i < 64; i++ {
+		signatureA[i+1] = byte(i)
+		signatureB[i+1] = byte(i + 64)
+	}
+	signatureC := append([]byte{0x1f}, bytes.Repeat([]byte{0xab}, 64)...)
+
+	transactions := []*protocol.Transaction{
+		makeTransaction(1, signatureA, signatureB),
+		makeTransaction(2, signatureC),
+		makeTransaction(3),
+	}
+
+	// Each transaction contributes the hash of its header and the hash of its concatenated signatures
+	leaves, err := CalculateTransactionMerkleLeaves(transactions[0])
+	assert.NoError(t, err)
+	assert.Len(t, leaves, 2)
+	assert.Equal(t, hashBytes(string(signatureA)+string(signatureB)), leaves[1])
+
+	merkleRoot, err := CalculateTransactionMerkleRoot(transactions)
+	assert.NoError(t, err)
+	assert.Equal(t, "1220d1666dd3e1b597f87a258ca860f8dd538bebe34cb004fe525f382e9a34137123", hex.EncodeToString(merkleRoot))
+}
+
+func TestValidateBlock(t *testing.T) {
+	key, err := GenerateKoinosKey()
+	assert.NoError(t, err)
+
+	block := makeTestBlock(t, key)
+	assert.NoError(t, ValidateBlock(block))
+
+	id, err := HashMessage(block.Header)
+	assert.NoError(t, err)
+	assert.Equal(t, id, block.Id)
+
+	assert.ErrorIs(t, ValidateBlock(&protocol.Block{}), ErrMissingHeader)
+
+	// Changing a transaction signature changes the merkle root
+	block.Transactions[0].Signatures[0][1] ^= 0xff
+	assert.ErrorIs(t, ValidateBlock(block), ErrTransactionMerkleRootMismatch)
+
+	block = makeTestBlock(t, key)
+	block.Header.Height++
+	assert.ErrorIs(t, ValidateBlock(block), ErrBlockIDMismatch)
+}
+
+func TestBlockSigner(t *testing.T) {
+	key, err := GenerateKoinosKey()
+	assert.NoError(t, err)
+	otherKey, err := GenerateKoinosKey()
+	assert.NoError(t, err)
+
+	block := makeTestBlock(t, key)
+
+	signer, err := RecoverBlockSigner(block)
+	assert.NoError(t, err)
+	assert.Equal(t, key.AddressBytes(), signer)
+
+	assert.NoError(t, VerifyBlockSignature(block, nil))
+	assert.NoError(t, VerifyBlockSignature(block, key.AddressBytes()))
+	assert.ErrorIs(t, VerifyBlockSignature(block, otherKey.AddressBytes()), ErrMissingSignature)
+
+	block.Signature = make([]byte, 65)
+	_, err = RecoverBlockSigner(block)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
@@ -7,3 +7,4 @@ go get ./...
 mkdir -p build
 go build -o build/koinos-util-golang *.go
 go build -o build/koinos-util-golang rpc/*.go
+go build -o build/koinos-util-golang signer/*.go
@@ -7,4 +7,73 @@ import (
 var (
 	// ErrInvalidNonce is the error returned when a nonce is invalid
 	ErrInvalidNonce = errors.New("invalid nonce")
+
+	// ErrMissingChainID is the error returned when a transaction has no chain ID
+	ErrMissingChainID = errors.New("missing chain id")
+
+	// ErrMissingHeader is the error returned when a transaction or block has no header
+	ErrMissingHeader = errors.New("missing header")
+
+	// ErrTransactionIDMismatch is the error returned when a transaction ID does not match its header
+	ErrTransactionIDMismatch = errors.New("transaction id mismatch")
+
+	// ErrOperationMerkleRootMismatch is the error returned when an operation merkle root does not match the operations
+	ErrOperationMerkleRootMismatch = errors.New("operation merkle root mismatch")
+
+	// ErrBlockIDMismatch is the error returned when a block ID does not match its header
+	ErrBlockIDMismatch = errors.New("block id mismatch")
+
+	// ErrTransactionMerkleRootMismatch is the error returned when a transaction merkle root does not match the transactions
+	ErrTransactionMerkleRootMismatch = errors.New("transaction merkle root mismatch")
+
+	// ErrMerkleIndexOutOfRange is the error returned when a merkle leaf index is out of range
+	ErrMerkleIndexOutOfRange = errors.New("merkle leaf index out of range")
+
+	// ErrMixedMerkleLeafCodes is the error returned when merkle leafs use different multihash codes
+	ErrMixedMerkleLeafCodes = errors.New("merkle leafs have mixed multihash codes")
+
+	// ErrInvalidMnemonic is the error returned when a mnemonic is malformed or has an invalid checksum
+	ErrInvalidMnemonic = errors.New("invalid mnemonic")
+
+	// ErrInvalidDerivationPath is the error returned when a BIP32 derivation path cannot be parsed
+	ErrInvalidDerivationPath = errors.New("invalid derivation path")
+
+	// ErrInvalidHDKey is the error returned when a derived key is not a valid secp256k1 key
+	ErrInvalidHDKey = errors.New("invalid hd key")
+
+	// ErrHDKeyNotPrivate is the error returned when a private HD key is required
+	ErrHDKeyNotPrivate = errors.New("hd key is not private")
+
+	// ErrInvalidPassphrase is the error returned when a keystore cannot be decrypted with the given passphrase
+	ErrInvalidPassphrase = errors.New("invalid passphrase")
+
+	// ErrUnsupportedKeystore is the error returned when a keystore uses an unknown version, cipher or kdf
+	ErrUnsupportedKeystore = errors.New("unsupported keystore")
+
+	// ErrKeystoreAddressMismatch is the error returned when a decrypted key does not match the keystore address
+	ErrKeystoreAddressMismatch = errors.New("keystore address mismatch")
+
+	// ErrNoTransactions is the error returned when at least one transaction is required
+	ErrNoTransactions = errors.New("no transactions")
+
+	// ErrInvalidAddress is the error returned when an address is malformed
+	ErrInvalidAddress = errors.New("invalid address")
+
+	// ErrAddressChecksumMismatch is the error returned when an address checksum does not match
+	ErrAddressChecksumMismatch = errors.New("address checksum mismatch")
+
+	// ErrWIFNetworkMismatch is the error returned when a WIF belongs to an unexpected network
+	ErrWIFNetworkMismatch = errors.New("wif network mismatch")
+
+	// ErrInvalidVanityPattern is the error returned when a vanity address can never, or practically never, match the requested pattern
+	ErrInvalidVanityPattern = errors.New("invalid vanity pattern")
+
+	// ErrInvalidID is the error returned when an ID is not a valid base58 ID
+	ErrInvalidID = errors.New("invalid id")
+
+	// ErrInvalidSignature is the error returned when a signature cannot be recovered
+	ErrInvalidSignature = errors.New("invalid signature")
+
+	// ErrMissingSignature is the error returned when a required signature is not present
+	ErrMissingSignature = errors.New("missing signature")
 )
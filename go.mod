@@ -10,7 +10,9 @@ require (
 	github.com/multiformats/go-multihash v0.1.0
 	github.com/shopspring/decimal v1.3.1
 	github.com/stretchr/testify v1.8.1
+	github.com/tyler-smith/go-bip39 v1.1.0
 	github.com/ybbus/jsonrpc/v3 v3.1.1
+	golang.org/x/crypto v0.0.0-20211108221036-ceb1ce70b4fa
 	google.golang.org/protobuf v1.30.0
 	gopkg.in/yaml.v2 v2.4.0
 )
@@ -0,0 +1,315 @@
+package util
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// HardenedKeyStart is the index of the first hardened child key
+const HardenedKeyStart uint32 = 0x80000000
+
+// Serialized length of an extended key, excluding the checksum
+const hdKeySerializedLen = 4 + 1 + 4 + 4 + 32 + 33
+
+var (
+	// masterKeySeed is the HMAC key used to derive a BIP32 master key from a seed
+	masterKeySeed = []byte("Bitcoin seed")
+
+	// Extended key versions, matching the xprv and xpub prefixes of other BIP32 implementations
+	hdPrivateKeyVersion = []byte{0x04, 0x88, 0xad, 0xe4}
+	hdPublicKeyVersion  = []byte{0x04, 0x88, 0xb2, 0x1e}
+)
+
+// HDKey is a BIP32 hierarchical deterministic key. It holds either a private key,
+// from which hardened and non-hardened children can be derived, or a public key,
+// from which only non-hardened children can be derived.
+type HDKey struct {
+	key       []byte
+	chainCode []byte
+	parentFP  []byte
+	depth     uint8
+	index     uint32
+	isPrivate bool
+}
+
+// NewMasterHDKey creates a master HD key from the given seed
+func NewMasterHDKey(seed []byte) (*HDKey, error) {
+	mac := hmac.New(sha512.New, masterKeySeed)
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	if !isValidPrivateKey(new(big.Int).SetBytes(sum[:32])) {
+		return nil, ErrInvalidHDKey
+	}
+
+	return &HDKey{key: sum[:32], chainCode: sum[32:], parentFP: []byte{0, 0, 0, 0}, isPrivate: true}, nil
+}
+
+// NewHDKeyFromMnemonic creates a master HD key from the given BIP39 mnemonic and optional passphrase
+func NewHDKeyFromMnemonic(mnemonic string, passphrase string) (*HDKey, error) {
+	seed, err := MnemonicToSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMasterHDKey(seed)
+}
+
+// ParseHDKey parses a base58 encoded extended private (xprv) or public (xpub) key
+func ParseHDKey(s string) (*HDKey, error) {
+	decoded := base58.Decode(s)
+	if len(decoded) != hdKeySerializedLen+4 {
+		return nil, ErrInvalidHDKey
+	}
+
+	payload := decoded[:hdKeySerializedLen]
+	cksum := chainhash.DoubleHashB(payload)[:4]
+	if !bytes.Equal(cksum, decoded[hdKeySerializedLen:]) {
+		return nil, btcutil.ErrChecksumMismatch
+	}
+
+	k := &HDKey{
+		depth:     payload[4],
+		parentFP:  payload[5:9],
+		index:     binary.BigEndian.Uint32(payload[9:13]),
+		chainCode: payload[13:45],
+	}
+
+	keyData := payload[45:]
+	switch {
+	case bytes.Equal(payload[:4], hdPrivateKeyVersion):
+		if keyData[0] != 0x00 || !isValidPrivateKey(new(big.Int).SetBytes(keyData[1:])) {
+			return nil, ErrInvalidHDKey
+		}
+		k.key = keyData[1:]
+		k.isPrivate = true
+	case bytes.Equal(payload[:4], hdPublicKeyVersion):
+		if _, err := btcec.ParsePubKey(keyData, btcec.S256()); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidHDKey, err)
+		}
+		k.key = keyData
+	default:
+		return nil, ErrInvalidHDKey
+	}
+
+	return k, nil
+}
+
+// String returns the base58 encoded extended key
+func (k *HDKey) String() string {
+	payload := make([]byte, 0, hdKeySerializedLen+4)
+	if k.isPrivate {
+		payload = append(payload, hdPrivateKeyVersion...)
+	} else {
+		payload = append(payload, hdPublicKeyVersion...)
+	}
+
+	payload = append(payload, k.depth)
+	payload = append(payload, k.parentFP...)
+	payload = append(payload, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(payload[len(payload)-4:], k.index)
+	payload = append(payload, k.chainCode...)
+
+	if k.isPrivate {
+		payload = append(payload, 0x00)
+		payload = paddedAppend(btcec.PrivKeyBytesLen, payload, k.key)
+	} else {
+		payload = append(payload, k.key...)
+	}
+
+	payload = append(payload, chainhash.DoubleHashB(payload)[:4]...)
+	return base58.Encode(payload)
+}
+
+// IsPrivate returns true if the key can derive private and hardened children
+func (k *HDKey) IsPrivate() bool {
+	return k.isPrivate
+}
+
+// Depth returns the depth of the key, the master key having a depth of zero
+func (k *HDKey) Depth() uint8 {
+	return k.depth
+}
+
+// Index returns the child index the key was derived with
+func (k *HDKey) Index() uint32 {
+	return k.index
+}
+
+// PublicBytes gets the compressed public key bytes
+func (k *HDKey) PublicBytes() []byte {
+	if !k.isPrivate {
+		return k.key
+	}
+
+	_, pubkey := btcec.PrivKeyFromBytes(btcec.S256(), k.key)
+	return pubkey.SerializeCompressed()
+}
+
+// AddressBytes fetches the byte address associated with this key
+func (k *HDKey) AddressBytes() []byte {
+	pubkey, _ := btcec.ParsePubKey(k.PublicBytes(), btcec.S256())
+	return addressFromPublicKey(pubkey)
+}
+
+// KoinosKey returns the private key as a KoinosKey
+func (k *HDKey) KoinosKey() (*KoinosKey, error) {
+	if !k.isPrivate {
+		return nil, ErrHDKeyNotPrivate
+	}
+
+	return NewKoinosKeyFromBytes(k.key)
+}
+
+// Neuter returns the public HD key, which can only derive non-hardened public children
+func (k *HDKey) Neuter() *HDKey {
+	if !k.isPrivate {
+		return k
+	}
+
+	return &HDKey{
+		key:       k.PublicBytes(),
+		chainCode: k.chainCode,
+		parentFP:  k.parentFP,
+		depth:     k.depth,
+		index:     k.index,
+	}
+}
+
+// Child derives the child key at the given index. Indices at or above HardenedKeyStart
+// derive hardened children, which require a private key.
+func (k *HDKey) Child(index uint32) (*HDKey, error) {
+	if k.depth == 255 {
+		return nil, ErrInvalidHDKey
+	}
+
+	hardened := index >= HardenedKeyStart
+	if hardened && !k.isPrivate {
+		return nil, ErrHDKeyNotPrivate
+	}
+
+	pubkeyBytes := k.PublicBytes()
+
+	data := make([]byte, 0, 33+4)
+	if hardened {
+		data = append(data, 0x00)
+		data = paddedAppend(btcec.PrivKeyBytesLen, data, k.key)
+	} else {
+		data = append(data, pubkeyBytes...)
+	}
+	data = append(data, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(data[len(data)-4:], index)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(btcec.S256().N) >= 0 {
+		return nil, ErrInvalidHDKey
+	}
+
+	child := &HDKey{
+		chainCode: sum[32:],
+		parentFP:  btcutil.Hash160(pubkeyBytes)[:4],
+		depth:     k.depth + 1,
+		index:     index,
+		isPrivate: k.isPrivate,
+	}
+
+	if k.isPrivate {
+		childKey := il.Add(il, new(big.Int).SetBytes(k.key))
+		childKey.Mod(childKey, btcec.S256().N)
+		if childKey.Sign() == 0 {
+			return nil, ErrInvalidHDKey
+		}
+
+		child.key = paddedAppend(btcec.PrivKeyBytesLen, nil, childKey.Bytes())
+	} else {
+		pubkey, err := btcec.ParsePubKey(pubkeyBytes, btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+
+		ilx, ily := btcec.S256().ScalarBaseMult(sum[:32])
+		x, y := btcec.S256().Add(ilx, ily, pubkey.X, pubkey.Y)
+		if x.Sign() == 0 && y.Sign() == 0 {
+			return nil, ErrInvalidHDKey
+		}
+
+		childKey := btcec.PublicKey{Curve: btcec.S256(), X: x, Y: y}
+		child.key = childKey.SerializeCompressed()
+	}
+
+	return child, nil
+}
+
+// DerivePath derives the key at the given BIP32 path. Absolute paths (m/44'/659'/0'/0/0)
+// may only be derived from a master key, relative paths (0/5) from any key.
+func (k *HDKey) DerivePath(path string) (*HDKey, error) {
+	indices, absolute, err := parseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if absolute && k.depth != 0 {
+		return nil, fmt.Errorf("%w: %s is absolute but key has depth %d", ErrInvalidDerivationPath, path, k.depth)
+	}
+
+	key := k
+	for _, index := range indices {
+		key, err = key.Child(index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+// parseDerivationPath parses a BIP32 derivation path such as m/44'/659'/0'/0/0 into child indices
+func parseDerivationPath(path string) ([]uint32, bool, error) {
+	segments := strings.Split(strings.TrimSpace(path), "/")
+
+	absolute := segments[0] == "m"
+	if absolute {
+		segments = segments[1:]
+	}
+
+	indices := make([]uint32, 0, len(segments))
+	for _, segment := range segments {
+		hardened := strings.HasSuffix(segment, "'") || strings.HasSuffix(segment, "h") || strings.HasSuffix(segment, "H")
+		if hardened {
+			segment = segment[:len(segment)-1]
+		}
+
+		index, err := strconv.ParseUint(segment, 10, 32)
+		if err != nil || uint32(index) >= HardenedKeyStart {
+			return nil, false, fmt.Errorf("%w: %s", ErrInvalidDerivationPath, path)
+		}
+
+		if hardened {
+			index += uint64(HardenedKeyStart)
+		}
+
+		indices = append(indices, uint32(index))
+	}
+
+	return indices, absolute, nil
+}
+
+// isValidPrivateKey checks that the given value is a valid secp256k1 private key
+func isValidPrivateKey(k *big.Int) bool {
+	return k.Sign() > 0 && k.Cmp(btcec.S256().N) < 0
+}
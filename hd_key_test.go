@@ -0,0 +1,96 @@
+package util
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHDKeyVectors(t *testing.T) {
+	// Test vector 1 from the BIP32 specification
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := NewMasterHDKey(seed)
+	assert.NoError(t, err)
+	assert.Equal(t, "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8", master.Neuter().String())
+
+	vectors := []struct {
+		path    string
+		private string
+	}{
+		{"m", "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{"m/0'", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{"m/0'/1", "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{"m/0'/1/2'", "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+		{"m/0'/1/2'/2", "0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4"},
+		{"m/0'/1/2'/2/1000000000", "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+	}
+
+	for _, v := range vectors {
+		key, err := master.DerivePath(v.path)
+		assert.NoError(t, err)
+
+		koinosKey, err := key.KoinosKey()
+		assert.NoError(t, err)
+		assert.Equal(t, v.private, hex.EncodeToString(koinosKey.PrivateBytes()))
+		assert.Equal(t, koinosKey.AddressBytes(), key.AddressBytes())
+
+		// Round trip through the serialized form
+		parsed, err := ParseHDKey(key.String())
+		assert.NoError(t, err)
+		assert.Equal(t, key.String(), parsed.String())
+		assert.True(t, parsed.IsPrivate())
+
+		parsed, err = ParseHDKey(key.Neuter().String())
+		assert.NoError(t, err)
+		assert.Equal(t, key.Neuter().String(), parsed.String())
+		assert.False(t, parsed.IsPrivate())
+	}
+}
+
+func TestHDKeyPublicDerivation(t *testing.T) {
+	mnemonic, err := GenerateMnemonic(128)
+	assert.NoError(t, err)
+
+	master, err := NewHDKeyFromMnemonic(mnemonic, "")
+	assert.NoError(t, err)
+
+	account, err := master.DerivePath("m/44'/659'/0'")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(3), account.Depth())
+	assert.Equal(t, HardenedKeyStart, account.Index())
+
+	xpub, err := ParseHDKey(account.Neuter().String())
+	assert.NoError(t, err)
+
+	// Addresses derived from the xpub match those derived from the private key
+	for i := 0; i < 5; i++ {
+		path := fmt.Sprintf("0/%d", i)
+		private, err := account.DerivePath(path)
+		assert.NoError(t, err)
+		public, err := xpub.DerivePath(path)
+		assert.NoError(t, err)
+
+		assert.Equal(t, private.AddressBytes(), public.AddressBytes())
+		assert.Equal(t, private.Neuter().String(), public.String())
+	}
+
+	key, err := account.DerivePath("0/0")
+	assert.NoError(t, err)
+	koinosKey, err := NewKoinosKeyFromMnemonic(mnemonic, "")
+	assert.NoError(t, err)
+	assert.Equal(t, koinosKey.AddressBytes(), key.AddressBytes())
+
+	_, err = xpub.Child(HardenedKeyStart)
+	assert.ErrorIs(t, err, ErrHDKeyNotPrivate)
+
+	_, err = xpub.KoinosKey()
+	assert.ErrorIs(t, err, ErrHDKeyNotPrivate)
+
+	_, err = account.DerivePath("m/0")
+	assert.ErrorIs(t, err, ErrInvalidDerivationPath)
+
+	_, err = ParseHDKey("xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet9")
+	assert.Error(t, err)
+}
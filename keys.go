@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/ecdsa"
 	"errors"
+	"fmt"
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/chaincfg"
@@ -17,9 +18,32 @@ import (
 
 const compressMagic byte = 0x01
 
+// WIF network IDs
+const (
+	MainNetWIFID byte = 0x80
+	TestNetWIFID byte = 0xef
+)
+
 // KoinosKey represents a set of keys
 type KoinosKey struct {
 	PrivateKey *ecdsa.PrivateKey
+
+	// WIF format the key was imported from, used when exporting it again
+	fromWIF         bool
+	wifNetID        byte
+	wifUncompressed bool
+}
+
+// WIF is a decoded WIF private key
+type WIF struct {
+	PrivateKey []byte
+	NetID      byte
+	Compressed bool
+}
+
+// String encodes the private key in WIF format
+func (w *WIF) String() string {
+	return EncodeWIF(w.PrivateKey, w.Compressed, w.NetID)
 }
 
 // GenerateKoinosKey generates a new set of keys
@@ -43,16 +67,82 @@ func NewKoinosKeyFromBytes(private []byte) (*KoinosKey, error) {
 	return &KoinosKey{PrivateKey: pk}, nil
 }
 
+// NewKoinosKeyFromWIF creates a new key set from a WIF string, remembering its network ID and compression
+func NewKoinosKeyFromWIF(wif string) (*KoinosKey, error) {
+	decoded, err := ParseWIF(wif)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := NewKoinosKeyFromBytes(decoded.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	key.fromWIF = true
+	key.wifNetID = decoded.NetID
+	key.wifUncompressed = !decoded.Compressed
+	return key, nil
+}
+
 // AddressBytes fetches the byte address associated with this key set
 func (keys *KoinosKey) AddressBytes() []byte {
-	_, pubkey := btcec.PrivKeyFromBytes(btcec.S256(), keys.PrivateBytes())
-	mainNetAddr, _ := btcutil.NewAddressPubKey(pubkey.SerializeCompressed(), &chaincfg.MainNetParams)
-	return base58.Decode(mainNetAddr.EncodeAddress())
+	return addressFromCompressedPublicKey(keys.PublicBytes())
 }
 
-// Private gets the private key in WIF format
+// AddressFromPublicKey computes the byte address of the given compressed or uncompressed public key.
+// Addresses are always derived from the compressed form of the key.
+func AddressFromPublicKey(pubkey []byte) ([]byte, error) {
+	key, err := btcec.ParsePubKey(pubkey, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+
+	return addressFromPublicKey(key), nil
+}
+
+// RecoverAddress recovers the byte address of the key that created the given compact signature of the digest
+func RecoverAddress(digest []byte, signature []byte) ([]byte, error) {
+	pubkey, _, err := btcec.RecoverCompact(btcec.S256(), signature, digest)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSignature, err)
+	}
+
+	return addressFromPublicKey(pubkey), nil
+}
+
+// addressFromPublicKey returns the byte address of the given public key
+func addressFromPublicKey(pubkey *btcec.PublicKey) []byte {
+	return addressFromCompressedPublicKey(pubkey.SerializeCompressed())
+}
+
+// addressFromCompressedPublicKey returns the byte address of the given compressed public key bytes
+func addressFromCompressedPublicKey(pubkey []byte) []byte {
+	address := make([]byte, 0, AddressLength)
+	address = append(address, chaincfg.MainNetParams.PubKeyHashAddrID)
+	address = append(address, btcutil.Hash160(pubkey)...)
+	return append(address, chainhash.DoubleHashB(address)[:4]...)
+}
+
+// Private gets the private key in WIF format. Keys imported with NewKoinosKeyFromWIF keep their
+// original network ID and compression, other keys use compressed mainnet WIF.
 func (keys *KoinosKey) Private() string {
-	return EncodeWIF(crypto.FromECDSA(keys.PrivateKey), true, 128)
+	return EncodeWIF(crypto.FromECDSA(keys.PrivateKey), keys.Compressed(), keys.NetID())
+}
+
+// NetID gets the WIF network ID of the key
+func (keys *KoinosKey) NetID() byte {
+	if !keys.fromWIF {
+		return MainNetWIFID
+	}
+
+	return keys.wifNetID
+}
+
+// Compressed returns true if the key is exported as a compressed WIF.
+// Addresses are always derived from the compressed public key.
+func (keys *KoinosKey) Compressed() bool {
+	return !keys.wifUncompressed
 }
 
 // Public gets the compressed public key in base58
@@ -106,6 +196,30 @@ func paddedAppend(size uint, dst, src []byte) []byte {
 
 // DecodeWIF decodes a WIF format string into bytes
 func DecodeWIF(wif string) ([]byte, error) {
+	decoded, err := ParseWIF(wif)
+	if err != nil {
+		return nil, err
+	}
+
+	return decoded.PrivateKey, nil
+}
+
+// DecodeWIFForNetwork decodes a WIF format string, expecting the given network ID
+func DecodeWIFForNetwork(wif string, netID byte) (*WIF, error) {
+	decoded, err := ParseWIF(wif)
+	if err != nil {
+		return nil, err
+	}
+
+	if decoded.NetID != netID {
+		return nil, fmt.Errorf("%w: expected 0x%02x, got 0x%02x", ErrWIFNetworkMismatch, netID, decoded.NetID)
+	}
+
+	return decoded, nil
+}
+
+// ParseWIF decodes a WIF format string into its private key, network ID and compression flag
+func ParseWIF(wif string) (*WIF, error) {
 	decoded := base58.Decode(wif)
 	if len(wif) > 0 && len(decoded) == 0 {
 		return nil, errors.New("unable to decode base 58 string")
@@ -142,16 +256,47 @@ func DecodeWIF(wif string) ([]byte, error) {
 		return nil, btcutil.ErrChecksumMismatch
 	}
 
-	//netID := decoded[0]
+	netID := decoded[0]
 	privKeyBytes := decoded[1 : 1+btcec.PrivKeyBytesLen]
 
-	return privKeyBytes, nil
+	return &WIF{PrivateKey: privKeyBytes, NetID: netID, Compressed: compress}, nil
+}
+
+// Address gets the byte address associated with this key set
+func (keys *KoinosKey) Address() []byte {
+	return keys.AddressBytes()
+}
+
+// PublicKey gets the compressed public key bytes
+func (keys *KoinosKey) PublicKey() []byte {
+	return keys.PublicBytes()
+}
+
+// SignDigest signs the given digest, returning a compact signature
+func (keys *KoinosKey) SignDigest(digest []byte) ([]byte, error) {
+	return signCompact(keys.PrivateBytes(), digest)
+}
+
+// signCompact signs the given digest with the given private key, returning a compact signature
+func signCompact(key []byte, digest []byte) ([]byte, error) {
+	privateKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), key)
+	return btcec.SignCompact(btcec.S256(), privateKey, digest, true)
 }
 
 // SignTransaction signs the transaction with the given key
 func SignTransaction(key []byte, tx *protocol.Transaction) error {
-	privateKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), key)
+	return signTransaction(tx, func(digest []byte) ([]byte, error) {
+		return signCompact(key, digest)
+	})
+}
+
+// SignTransactionWithSigner signs the transaction with the given signer
+func SignTransactionWithSigner(signer Signer, tx *protocol.Transaction) error {
+	return signTransaction(tx, signer.SignDigest)
+}
 
+// signTransaction signs the transaction ID with the given signing function
+func signTransaction(tx *protocol.Transaction, sign func([]byte) ([]byte, error)) error {
 	// Decode the mutlihashed ID
 	idBytes, err := multihash.Decode(tx.Id)
 	if err != nil {
@@ -159,7 +304,7 @@ func SignTransaction(key []byte, tx *protocol.Transaction) error {
 	}
 
 	// Sign the transaction ID
-	signatureBytes, err := btcec.SignCompact(btcec.S256(), privateKey, idBytes.Digest, true)
+	signatureBytes, err := sign(idBytes.Digest)
 	if err != nil {
 		return err
 	}
@@ -173,3 +318,35 @@ func SignTransaction(key []byte, tx *protocol.Transaction) error {
 
 	return nil
 }
+
+// SignBlock signs the block with the given key
+func SignBlock(key []byte, block *protocol.Block) error {
+	return signBlock(block, func(digest []byte) ([]byte, error) {
+		return signCompact(key, digest)
+	})
+}
+
+// SignBlockWithSigner signs the block with the given signer
+func SignBlockWithSigner(signer Signer, block *protocol.Block) error {
+	return signBlock(block, signer.SignDigest)
+}
+
+// signBlock signs the block ID with the given signing function
+func signBlock(block *protocol.Block, sign func([]byte) ([]byte, error)) error {
+	// Decode the mutlihashed ID
+	idBytes, err := multihash.Decode(block.Id)
+	if err != nil {
+		return err
+	}
+
+	// Sign the block ID
+	signatureBytes, err := sign(idBytes.Digest)
+	if err != nil {
+		return err
+	}
+
+	// Attach the signature data to the block
+	block.Signature = signatureBytes
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/btcsuite/btcutil/base58"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -75,3 +76,77 @@ func TestCompressedKey(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedAddress, base58.Encode(key.AddressBytes()))
 }
+
+func TestAddressFromPublicKey(t *testing.T) {
+	keyBytes, err := DecodeWIF("L1xAJ5axX33g7iBynn9bggE7GGBuaFdK6g1t6W52fQiRvQi73evQ")
+	assert.NoError(t, err)
+	key, err := NewKoinosKeyFromBytes(keyBytes)
+	assert.NoError(t, err)
+
+	expectedAddress := "13Sqw4TrwdZ8RZ9UVfqqA2i3mrbeumcWba"
+
+	address, err := AddressFromPublicKey(key.PublicBytes())
+	assert.NoError(t, err)
+	assert.Equal(t, expectedAddress, base58.Encode(address))
+
+	address, err = AddressFromPublicKey(crypto.FromECDSAPub(&key.PrivateKey.PublicKey))
+	assert.NoError(t, err)
+	assert.Equal(t, expectedAddress, base58.Encode(address))
+
+	_, err = AddressFromPublicKey([]byte{0x02, 0x01})
+	assert.Error(t, err)
+
+	digest := sha256.Sum256([]byte("digest"))
+	signature, err := key.SignDigest(digest[:])
+	assert.NoError(t, err)
+
+	address, err = RecoverAddress(digest[:], signature)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedAddress, base58.Encode(address))
+
+	_, err = RecoverAddress(digest[:], make([]byte, 65))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestWIFFormat(t *testing.T) {
+	// Uncompressed mainnet keys round trip
+	uncompressedWIF := "5JtU2c2MHKb8xSeNvsZJpxZRXeRg6iq6uwc6EUtDA9zsWM6B4c5"
+	decoded, err := ParseWIF(uncompressedWIF)
+	assert.NoError(t, err)
+	assert.Equal(t, MainNetWIFID, decoded.NetID)
+	assert.False(t, decoded.Compressed)
+	assert.Equal(t, uncompressedWIF, decoded.String())
+
+	key, err := NewKoinosKeyFromWIF(uncompressedWIF)
+	assert.NoError(t, err)
+	assert.False(t, key.Compressed())
+	assert.Equal(t, uncompressedWIF, key.Private())
+	assert.Equal(t, "13Sqw4TrwdZ8RZ9UVfqqA2i3mrbeumcWba", base58.Encode(key.AddressBytes()))
+
+	// Testnet keys round trip
+	testnetWIF := EncodeWIF(key.PrivateBytes(), true, TestNetWIFID)
+	key, err = NewKoinosKeyFromWIF(testnetWIF)
+	assert.NoError(t, err)
+	assert.Equal(t, TestNetWIFID, key.NetID())
+	assert.True(t, key.Compressed())
+	assert.Equal(t, testnetWIF, key.Private())
+
+	_, err = DecodeWIFForNetwork(testnetWIF, MainNetWIFID)
+	assert.ErrorIs(t, err, ErrWIFNetworkMismatch)
+
+	decoded, err = DecodeWIFForNetwork(testnetWIF, TestNetWIFID)
+	assert.NoError(t, err)
+	assert.Equal(t, key.PrivateBytes(), decoded.PrivateKey)
+
+	// A zero network ID is kept as is
+	zeroNetWIF := EncodeWIF(key.PrivateBytes(), true, 0x00)
+	key, err = NewKoinosKeyFromWIF(zeroNetWIF)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0x00), key.NetID())
+	assert.Equal(t, zeroNetWIF, key.Private())
+
+	// Keys created from bytes default to compressed mainnet
+	key, err = NewKoinosKeyFromBytes(key.PrivateBytes())
+	assert.NoError(t, err)
+	assert.Equal(t, "L1xAJ5axX33g7iBynn9bggE7GGBuaFdK6g1t6W52fQiRvQi73evQ", key.Private())
+}
@@ -0,0 +1,305 @@
+package util
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/btcsuite/btcutil/base58"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// KeystoreVersion is the version of the keystore format written by this package
+	KeystoreVersion = 1
+
+	// KeystoreDirName is the name of the keystore directory within the base directory
+	KeystoreDirName = "keystore"
+
+	// StandardScryptN is the scrypt N parameter recommended for keys stored at rest
+	StandardScryptN = 1 << 18
+
+	// LightScryptN is a cheaper scrypt N parameter, for use where fast decryption is required
+	LightScryptN = 1 << 12
+
+	keystoreCipher = "aes-256-gcm"
+	keystoreKDF    = "scrypt"
+	scryptR        = 8
+	scryptP        = 1
+	scryptKeyLen   = 32
+	scryptSaltLen  = 32
+
+	keystoreDirPerm = 0700
+)
+
+// Keystore is the versioned JSON representation of an encrypted private key
+type Keystore struct {
+	Version int            `json:"version"`
+	Address string         `json:"address"`
+	Crypto  KeystoreCrypto `json:"crypto"`
+}
+
+// KeystoreCrypto contains the cipher and key derivation parameters of an encrypted private key
+type KeystoreCrypto struct {
+	Cipher     string         `json:"cipher"`
+	CipherText string         `json:"ciphertext"`
+	Nonce      string         `json:"nonce"`
+	KDF        string         `json:"kdf"`
+	KDFParams  KeystoreScrypt `json:"kdfparams"`
+}
+
+// KeystoreScrypt contains the scrypt key derivation parameters
+type KeystoreScrypt struct {
+	N      int    `json:"n"`
+	R      int    `json:"r"`
+	P      int    `json:"p"`
+	Salt   string `json:"salt"`
+	KeyLen int    `json:"keylen"`
+}
+
+// EncryptKey encrypts the given key with the given passphrase, using the given scrypt N parameter
+func EncryptKey(key *KoinosKey, passphrase string, scryptN int) (*Keystore, error) {
+	if err := validateScryptParams(KeystoreScrypt{N: scryptN, R: scryptR, P: scryptP, KeyLen: scryptKeyLen}); err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, scryptSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newKeystoreCipher(derivedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	cipherText := gcm.Seal(nil, nonce, key.PrivateBytes(), nil)
+
+	return &Keystore{
+		Version: KeystoreVersion,
+		Address: base58.Encode(key.AddressBytes()),
+		Crypto: KeystoreCrypto{
+			Cipher:     keystoreCipher,
+			CipherText: hex.EncodeToString(cipherText),
+			Nonce:      hex.EncodeToString(nonce),
+			KDF:        keystoreKDF,
+			KDFParams: KeystoreScrypt{
+				N:      scryptN,
+				R:      scryptR,
+				P:      scryptP,
+				Salt:   hex.EncodeToString(salt),
+				KeyLen: scryptKeyLen,
+			},
+		},
+	}, nil
+}
+
+// Decrypt decrypts the key with the given passphrase
+func (ks *Keystore) Decrypt(passphrase string) (*KoinosKey, error) {
+	if ks.Version != KeystoreVersion || ks.Crypto.Cipher != keystoreCipher || ks.Crypto.KDF != keystoreKDF {
+		return nil, fmt.Errorf("%w: version %d, cipher %s, kdf %s", ErrUnsupportedKeystore, ks.Version, ks.Crypto.Cipher, ks.Crypto.KDF)
+	}
+
+	salt, err := hex.DecodeString(ks.Crypto.KDFParams.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := hex.DecodeString(ks.Crypto.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	cipherText, err := hex.DecodeString(ks.Crypto.CipherText)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bound the parameters before deriving, a crafted file could otherwise exhaust memory
+	params := ks.Crypto.KDFParams
+	if err := validateScryptParams(params); err != nil {
+		return nil, err
+	}
+
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, params.KeyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newKeystoreCipher(derivedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("%w: invalid nonce length", ErrUnsupportedKeystore)
+	}
+
+	private, err := gcm.Open(nil, nonce, cipherText, nil)
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+
+	key, err := NewKoinosKeyFromBytes(private)
+	if err != nil {
+		return nil, err
+	}
+
+	if base58.Encode(key.AddressBytes()) != ks.Address {
+		return nil, ErrKeystoreAddressMismatch
+	}
+
+	return key, nil
+}
+
+// validateScryptParams checks that the scrypt parameters are within the range written by this package
+func validateScryptParams(params KeystoreScrypt) error {
+	if params.N <= 1 || params.N > StandardScryptN || params.N&(params.N-1) != 0 {
+		return fmt.Errorf("%w: scrypt n %d", ErrUnsupportedKeystore, params.N)
+	}
+
+	if params.R != scryptR || params.P != scryptP || params.KeyLen != scryptKeyLen {
+		return fmt.Errorf("%w: scrypt r %d, p %d, keylen %d", ErrUnsupportedKeystore, params.R, params.P, params.KeyLen)
+	}
+
+	return nil
+}
+
+// newKeystoreCipher creates the AES-GCM cipher for the given derived key
+func newKeystoreCipher(derivedKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(derivedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// SaveKeystore encrypts the given key and writes it to the given file
+func SaveKeystore(path string, key *KoinosKey, passphrase string, scryptN int) error {
+	ks, err := EncryptKey(key, passphrase, scryptN)
+	if err != nil {
+		return err
+	}
+
+	return writeKeystore(path, ks)
+}
+
+// LoadKeystore reads the keystore file at the given path and decrypts the key
+func LoadKeystore(path string, passphrase string) (*KoinosKey, error) {
+	ks, err := readKeystore(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ks.Decrypt(passphrase)
+}
+
+// ChangeKeystorePassphrase re-encrypts the keystore file at the given path with a new passphrase.
+// The scrypt parameters of the existing file are kept.
+func ChangeKeystorePassphrase(path string, oldPassphrase string, newPassphrase string) error {
+	ks, err := readKeystore(path)
+	if err != nil {
+		return err
+	}
+
+	key, err := ks.Decrypt(oldPassphrase)
+	if err != nil {
+		return err
+	}
+
+	return SaveKeystore(path, key, newPassphrase, ks.Crypto.KDFParams.N)
+}
+
+// GetKeystoreDir forms the keystore directory from the given base directory
+func GetKeystoreDir(baseDir string) string {
+	return filepath.Join(baseDir, KeystoreDirName)
+}
+
+// GetKeystorePath forms the keystore file path of the given address within the base directory
+func GetKeystorePath(baseDir string, address []byte) string {
+	return filepath.Join(GetKeystoreDir(baseDir), base58.Encode(address)+".json")
+}
+
+// SaveKeystoreToDir encrypts the given key and writes it to the keystore directory of the given base directory,
+// as returned by InitBaseDir. The keystore directory is created, or restricted, to be accessible only by the
+// owner. It returns the path of the written file.
+func SaveKeystoreToDir(baseDir string, key *KoinosKey, passphrase string, scryptN int) (string, error) {
+	dir := GetKeystoreDir(baseDir)
+	if err := os.MkdirAll(dir, keystoreDirPerm); err != nil {
+		return "", err
+	}
+
+	// MkdirAll leaves the permissions of an existing directory untouched
+	if err := os.Chmod(dir, keystoreDirPerm); err != nil {
+		return "", err
+	}
+
+	path := GetKeystorePath(baseDir, key.AddressBytes())
+	if err := SaveKeystore(path, key, passphrase, scryptN); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// readKeystore reads and parses the keystore file at the given path
+func readKeystore(path string) (*Keystore, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ks := &Keystore{}
+	if err := json.Unmarshal(data, ks); err != nil {
+		return nil, err
+	}
+
+	return ks, nil
+}
+
+// writeKeystore atomically writes the keystore file, readable only by the owner
+func writeKeystore(path string, ks *Keystore) error {
+	data, err := json.MarshalIndent(ks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err := f.Chmod(0600); err != nil {
+		f.Close()
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
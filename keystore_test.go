@@ -0,0 +1,102 @@
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeystore(t *testing.T) {
+	baseDir, err := ioutil.TempDir("", "koinos-keystore")
+	assert.NoError(t, err)
+	defer os.RemoveAll(baseDir)
+
+	key, err := GenerateKoinosKey()
+	assert.NoError(t, err)
+
+	// An existing keystore directory is restricted as well
+	assert.NoError(t, os.Mkdir(GetKeystoreDir(baseDir), 0755))
+
+	path, err := SaveKeystoreToDir(baseDir, key, "password", LightScryptN)
+	assert.NoError(t, err)
+	assert.Equal(t, GetKeystorePath(baseDir, key.AddressBytes()), path)
+	assert.Equal(t, filepath.Join(baseDir, KeystoreDirName), filepath.Dir(path))
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	info, err = os.Stat(GetKeystoreDir(baseDir))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+	loaded, err := LoadKeystore(path, "password")
+	assert.NoError(t, err)
+	assert.Equal(t, key.PrivateBytes(), loaded.PrivateBytes())
+
+	_, err = LoadKeystore(path, "wrong")
+	assert.ErrorIs(t, err, ErrInvalidPassphrase)
+
+	assert.NoError(t, ChangeKeystorePassphrase(path, "password", "new password"))
+	assert.ErrorIs(t, ChangeKeystorePassphrase(path, "password", "other"), ErrInvalidPassphrase)
+
+	_, err = LoadKeystore(path, "password")
+	assert.ErrorIs(t, err, ErrInvalidPassphrase)
+
+	loaded, err = LoadKeystore(path, "new password")
+	assert.NoError(t, err)
+	assert.Equal(t, key.PrivateBytes(), loaded.PrivateBytes())
+
+	files, err := ioutil.ReadDir(GetKeystoreDir(baseDir))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestKeystoreTampering(t *testing.T) {
+	key, err := GenerateKoinosKey()
+	assert.NoError(t, err)
+	otherKey, err := GenerateKoinosKey()
+	assert.NoError(t, err)
+
+	ks, err := EncryptKey(key, "password", LightScryptN)
+	assert.NoError(t, err)
+	assert.Equal(t, KeystoreVersion, ks.Version)
+
+	ks.Address = base58.Encode(otherKey.AddressBytes())
+	_, err = ks.Decrypt("password")
+	assert.ErrorIs(t, err, ErrKeystoreAddressMismatch)
+
+	ks.Version = KeystoreVersion + 1
+	_, err = ks.Decrypt("password")
+	assert.ErrorIs(t, err, ErrUnsupportedKeystore)
+}
+
+func TestKeystoreScryptParams(t *testing.T) {
+	key, err := GenerateKoinosKey()
+	assert.NoError(t, err)
+
+	_, err = EncryptKey(key, "password", StandardScryptN*2)
+	assert.ErrorIs(t, err, ErrUnsupportedKeystore)
+
+	// Parameters are checked before the key is derived
+	tamper := []func(params *KeystoreScrypt){
+		func(params *KeystoreScrypt) { params.N = 1 << 30 },
+		func(params *KeystoreScrypt) { params.N = LightScryptN + 1 },
+		func(params *KeystoreScrypt) { params.R = 1 << 20 },
+		func(params *KeystoreScrypt) { params.P = 2 },
+		func(params *KeystoreScrypt) { params.KeyLen = 16 },
+	}
+
+	for _, modify := range tamper {
+		ks, err := EncryptKey(key, "password", LightScryptN)
+		assert.NoError(t, err)
+
+		modify(&ks.Crypto.KDFParams)
+		_, err = ks.Decrypt("password")
+		assert.ErrorIs(t, err, ErrUnsupportedKeystore)
+	}
+}
@@ -0,0 +1,87 @@
+package util
+
+import (
+	"crypto/sha256"
+	"hash"
+
+	"github.com/multiformats/go-multihash"
+)
+
+// MerkleAccumulator calculates a merkle root from leafs appended one at a time.
+// It only keeps the roots of the complete subtrees seen so far, requiring O(log n) memory,
+// and produces the same root as CalculateMerkleRoot.
+type MerkleAccumulator struct {
+	hasher hash.Hash
+	peaks  [][]byte
+	count  uint64
+}
+
+// NewMerkleAccumulator creates a new, empty merkle accumulator
+func NewMerkleAccumulator() *MerkleAccumulator {
+	return &MerkleAccumulator{hasher: sha256.New()}
+}
+
+// Append adds the given leaf to the accumulator. A leaf that is not a valid multihash is rejected
+// and leaves the accumulator unchanged.
+func (a *MerkleAccumulator) Append(leaf []byte) error {
+	if _, err := multihash.Decode(leaf); err != nil {
+		return err
+	}
+
+	node := leaf
+
+	// Merge complete subtrees of equal size, like carrying in a binary counter
+	level := 0
+	for ; level < len(a.peaks) && a.peaks[level] != nil; level++ {
+		var err error
+		node, err = hashMerkleNodes(a.hasher, multihash.SHA2_256, a.peaks[level], node)
+		if err != nil {
+			return err
+		}
+
+		a.peaks[level] = nil
+	}
+
+	if level == len(a.peaks) {
+		a.peaks = append(a.peaks, node)
+	} else {
+		a.peaks[level] = node
+	}
+
+	a.count++
+	return nil
+}
+
+// Count returns the number of leafs appended to the accumulator
+func (a *MerkleAccumulator) Count() uint64 {
+	return a.count
+}
+
+// Root calculates the merkle root of the leafs appended so far
+func (a *MerkleAccumulator) Root() ([]byte, error) {
+	if a.count == 0 {
+		a.hasher.Reset()
+		return multihash.Encode(a.hasher.Sum(nil), multihash.SHA2_256)
+	}
+
+	// Odd nodes are promoted, so the smaller subtrees on the right are combined first
+	var root []byte
+	for _, peak := range a.peaks {
+		if peak == nil {
+			continue
+		}
+
+		if root == nil {
+			root = peak
+			continue
+		}
+
+		var err error
+		root, err = hashMerkleNodes(a.hasher, multihash.SHA2_256, peak, root)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return root, nil
+}
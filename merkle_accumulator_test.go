@@ -0,0 +1,55 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerkleAccumulator(t *testing.T) {
+	accumulator := NewMerkleAccumulator()
+	var hashes [][]byte
+
+	for n := 0; n <= 40; n++ {
+		if n > 0 {
+			mh, _ := multihash.Sum([]byte{byte(n)}, multihash.SHA2_256, -1)
+			hashes = append(hashes, mh)
+			assert.NoError(t, accumulator.Append(mh))
+		}
+
+		expected, err := CalculateMerkleRoot(append([][]byte{}, hashes...))
+		assert.NoError(t, err)
+
+		root, err := accumulator.Root()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, root)
+		assert.Equal(t, uint64(n), accumulator.Count())
+	}
+}
+
+func TestMerkleAccumulatorInvalidLeaf(t *testing.T) {
+	accumulator := NewMerkleAccumulator()
+
+	// A malformed only leaf does not become the root
+	assert.Error(t, accumulator.Append([]byte{0xff}))
+	assert.Equal(t, uint64(0), accumulator.Count())
+
+	var hashes [][]byte
+	for n := 1; n <= 3; n++ {
+		mh, _ := multihash.Sum([]byte{byte(n)}, multihash.SHA2_256, -1)
+		hashes = append(hashes, mh)
+		assert.NoError(t, accumulator.Append(mh))
+
+		// The accumulator keeps working after a rejected leaf
+		assert.Error(t, accumulator.Append([]byte{0x12, 0x20}))
+	}
+
+	expected, err := CalculateMerkleRoot(append([][]byte{}, hashes...))
+	assert.NoError(t, err)
+
+	root, err := accumulator.Root()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, root)
+	assert.Equal(t, uint64(3), accumulator.Count())
+}
@@ -0,0 +1,96 @@
+package util
+
+import (
+	"crypto/sha256"
+	"runtime"
+	"sync"
+
+	"github.com/multiformats/go-multihash"
+)
+
+// minParallelMerklePairs is the number of node pairs below which a level is hashed sequentially
+const minParallelMerklePairs = 256
+
+// CalculateMerkleRootParallel calculates the merkle root for given leafs using the given number of workers.
+// If workers is less than one, runtime.NumCPU() workers are used. The leafs are not modified and the
+// result is identical to CalculateMerkleRoot.
+func CalculateMerkleRootParallel(leaves [][]byte, workers int) ([]byte, error) {
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	if len(leaves) == 0 {
+		return multihash.Encode(sha256.New().Sum(nil), multihash.SHA2_256)
+	}
+
+	nodes := make([][]byte, len(leaves))
+	copy(nodes, leaves)
+
+	for len(nodes) > 1 {
+		pairs := len(nodes) / 2
+		parents := make([][]byte, (len(nodes)+1)/2)
+
+		// Promote the odd node
+		if len(nodes)%2 == 1 {
+			parents[pairs] = nodes[len(nodes)-1]
+		}
+
+		levelWorkers := workers
+		if pairs < minParallelMerklePairs {
+			levelWorkers = 1
+		}
+
+		if err := hashMerkleLevel(nodes, parents, pairs, levelWorkers); err != nil {
+			return nil, err
+		}
+
+		nodes = parents
+	}
+
+	return nodes[0], nil
+}
+
+// hashMerkleLevel hashes the node pairs of a level into parents, splitting the pairs evenly between workers
+func hashMerkleLevel(nodes [][]byte, parents [][]byte, pairs int, workers int) error {
+	if workers > pairs {
+		workers = pairs
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, workers)
+	chunk := (pairs + workers - 1) / workers
+
+	for w := 0; w < workers; w++ {
+		start := w * chunk
+		end := start + chunk
+		if end > pairs {
+			end = pairs
+		}
+
+		wg.Add(1)
+		go func(w int, start int, end int) {
+			defer wg.Done()
+
+			hasher := sha256.New()
+			for i := start; i < end; i++ {
+				sum, err := hashMerkleNodes(hasher, multihash.SHA2_256, nodes[2*i], nodes[2*i+1])
+				if err != nil {
+					errs[w] = err
+					return
+				}
+
+				parents[i] = sum
+			}
+		}(w, start, end)
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
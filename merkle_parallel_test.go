@@ -0,0 +1,66 @@
+package util
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeTestLeaves(n int) [][]byte {
+	leaves := make([][]byte, n)
+	buf := make([]byte, 8)
+	for i := range leaves {
+		binary.BigEndian.PutUint64(buf, uint64(i))
+		leaves[i], _ = multihash.Sum(buf, multihash.SHA2_256, -1)
+	}
+
+	return leaves
+}
+
+func TestMerkleRootParallel(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 9, 511, 512, 513, 1000, 4097} {
+		leaves := makeTestLeaves(n)
+
+		expected, err := CalculateMerkleRoot(append([][]byte{}, leaves...))
+		assert.NoError(t, err)
+
+		for _, workers := range []int{0, 1, 3, 8} {
+			root, err := CalculateMerkleRootParallel(leaves, workers)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, root)
+		}
+
+		assert.Equal(t, makeTestLeaves(n), leaves)
+	}
+
+	leaves := makeTestLeaves(1000)
+	leaves[700] = []byte{0xff}
+	_, err := CalculateMerkleRootParallel(leaves, 4)
+	assert.Error(t, err)
+}
+
+func BenchmarkMerkleRoot(b *testing.B) {
+	leaves := makeTestLeaves(50000)
+	nodes := make([][]byte, len(leaves))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(nodes, leaves)
+		if _, err := CalculateMerkleRoot(nodes); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMerkleRootParallel(b *testing.B) {
+	leaves := makeTestLeaves(50000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := CalculateMerkleRootParallel(leaves, 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
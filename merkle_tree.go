@@ -1,7 +1,9 @@
 package util
 
 import (
+	"bytes"
 	"crypto/sha256"
+	"hash"
 
 	"github.com/multiformats/go-multihash"
 )
@@ -17,25 +19,62 @@ func CalculateMerkleRoot(nodes [][]byte) ([]byte, error) {
 	for len(nodes) > 1 {
 		for i := 0; i < len(nodes); i += 2 {
 			if i+1 < len(nodes) {
-				mHash, err := multihash.Decode(nodes[i])
+				sum, err := hashMerkleNodes(hasher, multihash.SHA2_256, nodes[i], nodes[i+1])
 				if err != nil {
 					return nil, err
 				}
-				hasher.Write(mHash.Digest)
 
-				mHash, err = multihash.Decode(nodes[i+1])
-				if err != nil {
-					return nil, err
-				}
-				hasher.Write(mHash.Digest)
+				nodes[i/2] = sum
+			} else {
+				nodes[i/2] = nodes[i]
+			}
+		}
+
+		nodes = nodes[:(len(nodes)+1)/2]
+	}
+
+	return nodes[0], nil
+}
+
+// CalculateMerkleRootWithCode calculates the merkle root for given leafs, hashing parent nodes
+// with the hash function of the given multihash code. The leafs are not modified and must all
+// share the same multihash code.
+func CalculateMerkleRootWithCode(leaves [][]byte, code uint64) ([]byte, error) {
+	hasher, err := multihash.GetHasher(code)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(leaves) == 0 {
+		return multihash.Encode(hasher.Sum(nil), code)
+	}
+
+	var leafCode uint64
+	for i, leaf := range leaves {
+		mHash, err := multihash.Decode(leaf)
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			leafCode = mHash.Code
+		} else if mHash.Code != leafCode {
+			return nil, ErrMixedMerkleLeafCodes
+		}
+	}
 
-				sum, err := multihash.Encode(hasher.Sum(nil), multihash.SHA2_256)
+	nodes := make([][]byte, len(leaves))
+	copy(nodes, leaves)
+
+	for len(nodes) > 1 {
+		for i := 0; i < len(nodes); i += 2 {
+			if i+1 < len(nodes) {
+				sum, err := hashMerkleNodes(hasher, code, nodes[i], nodes[i+1])
 				if err != nil {
 					return nil, err
 				}
 
 				nodes[i/2] = sum
-				hasher.Reset()
 			} else {
 				nodes[i/2] = nodes[i]
 			}
@@ -46,3 +85,149 @@ func CalculateMerkleRoot(nodes [][]byte) ([]byte, error) {
 
 	return nodes[0], nil
 }
+
+// hashMerkleNodes calculates the parent of the given left and right merkle nodes
+func hashMerkleNodes(hasher hash.Hash, code uint64, left []byte, right []byte) ([]byte, error) {
+	defer hasher.Reset()
+
+	mHash, err := multihash.Decode(left)
+	if err != nil {
+		return nil, err
+	}
+	hasher.Write(mHash.Digest)
+
+	mHash, err = multihash.Decode(right)
+	if err != nil {
+		return nil, err
+	}
+	hasher.Write(mHash.Digest)
+
+	return multihash.Encode(hasher.Sum(nil), code)
+}
+
+// MerkleTree is a merkle tree that retains every level, allowing inclusion proofs to be generated.
+// Odd nodes are promoted to the next level unchanged, matching CalculateMerkleRoot.
+type MerkleTree struct {
+	levels [][][]byte
+}
+
+// NewMerkleTree creates a merkle tree from the given leafs. The leafs are not modified.
+func NewMerkleTree(leaves [][]byte) (*MerkleTree, error) {
+	hasher := sha256.New()
+
+	if len(leaves) == 0 {
+		root, err := multihash.Encode(hasher.Sum(nil), multihash.SHA2_256)
+		if err != nil {
+			return nil, err
+		}
+
+		return &MerkleTree{levels: [][][]byte{{}, {root}}}, nil
+	}
+
+	nodes := make([][]byte, len(leaves))
+	copy(nodes, leaves)
+	levels := [][][]byte{nodes}
+
+	for len(nodes) > 1 {
+		parents := make([][]byte, (len(nodes)+1)/2)
+		for i := 0; i < len(nodes); i += 2 {
+			if i+1 < len(nodes) {
+				sum, err := hashMerkleNodes(hasher, multihash.SHA2_256, nodes[i], nodes[i+1])
+				if err != nil {
+					return nil, err
+				}
+
+				parents[i/2] = sum
+			} else {
+				parents[i/2] = nodes[i]
+			}
+		}
+
+		levels = append(levels, parents)
+		nodes = parents
+	}
+
+	return &MerkleTree{levels: levels}, nil
+}
+
+// Root returns the merkle root of the tree
+func (t *MerkleTree) Root() []byte {
+	return t.levels[len(t.levels)-1][0]
+}
+
+// Leaves returns the leafs of the tree
+func (t *MerkleTree) Leaves() [][]byte {
+	return t.levels[0]
+}
+
+// Proof generates an inclusion proof for the leaf at the given index. The proof contains the sibling
+// of the node at each level, from the leafs up. A nil entry means the node was promoted without a sibling.
+func (t *MerkleTree) Proof(index int) ([][]byte, error) {
+	if index < 0 || index >= len(t.levels[0]) {
+		return nil, ErrMerkleIndexOutOfRange
+	}
+
+	proof := make([][]byte, 0, len(t.levels)-1)
+	for _, nodes := range t.levels[:len(t.levels)-1] {
+		sibling := index ^ 1
+		if sibling < len(nodes) {
+			proof = append(proof, nodes[sibling])
+		} else {
+			proof = append(proof, nil)
+		}
+
+		index /= 2
+	}
+
+	return proof, nil
+}
+
+// VerifyMerkleProof verifies that the given leaf is included at the given index of the tree with the given
+// root and number of leafs. The leaf count determines where nodes are promoted, so the proof must have one
+// entry per level, with nil entries exactly where the node has no sibling.
+func VerifyMerkleProof(root []byte, leaf []byte, index int, leafCount int, proof [][]byte) (bool, error) {
+	if index < 0 || index >= leafCount {
+		return false, ErrMerkleIndexOutOfRange
+	}
+
+	hasher := sha256.New()
+	node := leaf
+	width := leafCount
+	level := 0
+
+	for ; width > 1; level++ {
+		if level >= len(proof) {
+			return false, nil
+		}
+
+		sibling := proof[level]
+
+		// Only the last node of an odd level is promoted
+		promoted := index%2 == 0 && index+1 >= width
+		if promoted != (sibling == nil) {
+			return false, nil
+		}
+
+		if !promoted {
+			var err error
+			if index%2 == 0 {
+				node, err = hashMerkleNodes(hasher, multihash.SHA2_256, node, sibling)
+			} else {
+				node, err = hashMerkleNodes(hasher, multihash.SHA2_256, sibling, node)
+			}
+
+			if err != nil {
+				return false, err
+			}
+		}
+
+		index /= 2
+		width = (width + 1) / 2
+	}
+
+	if level != len(proof) {
+		return false, nil
+	}
+
+	return bytes.Equal(node, root), nil
+}
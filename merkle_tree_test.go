@@ -72,3 +72,157 @@ func checkLeaves(t *testing.T, expected []byte, leaves [][]byte) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected, root)
 }
+
+func TestMerkleProof(t *testing.T) {
+	values := []string{"the", "quick", "brown", "fox", "jumps", "over", "a", "lazy", "dog"}
+	hasher := sha256.New()
+
+	for n := 1; n <= len(values); n++ {
+		var hashes [][]byte
+		for _, word := range values[:n] {
+			hasher.Write([]byte(word))
+			mh, _ := multihash.Encode(hasher.Sum(nil), multihash.SHA2_256)
+			hashes = append(hashes, mh)
+			hasher.Reset()
+		}
+
+		tree, err := NewMerkleTree(hashes)
+		assert.NoError(t, err)
+		assert.Equal(t, hashes, tree.Leaves())
+
+		expected, err := CalculateMerkleRoot(append([][]byte{}, hashes...))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, tree.Root())
+
+		for i, leaf := range hashes {
+			proof, err := tree.Proof(i)
+			assert.NoError(t, err)
+
+			ok, err := VerifyMerkleProof(tree.Root(), leaf, i, n, proof)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+
+			// Proof must not verify for a different leaf or index
+			ok, err = VerifyMerkleProof(tree.Root(), hashes[(i+1)%n], i, n, proof)
+			assert.NoError(t, err)
+			assert.Equal(t, n == 1, ok)
+
+			if n > 1 {
+				ok, err = VerifyMerkleProof(tree.Root(), leaf, (i+1)%n, n, proof)
+				assert.NoError(t, err)
+				assert.False(t, ok)
+			}
+		}
+
+		_, err = tree.Proof(n)
+		assert.ErrorIs(t, err, ErrMerkleIndexOutOfRange)
+
+		_, err = VerifyMerkleProof(tree.Root(), hashes[0], n, n, nil)
+		assert.ErrorIs(t, err, ErrMerkleIndexOutOfRange)
+	}
+
+	tree, err := NewMerkleTree([][]byte{})
+	assert.NoError(t, err)
+	expected, _ := multihash.Encode(sha256.New().Sum(nil), multihash.SHA2_256)
+	assert.Equal(t, expected, tree.Root())
+	_, err = tree.Proof(0)
+	assert.ErrorIs(t, err, ErrMerkleIndexOutOfRange)
+}
+
+func TestMerkleProofShape(t *testing.T) {
+	hasher := sha256.New()
+	var hashes [][]byte
+	for _, word := range []string{"a", "b", "c", "d", "e"} {
+		hasher.Write([]byte(word))
+		mh, _ := multihash.Encode(hasher.Sum(nil), multihash.SHA2_256)
+		hashes = append(hashes, mh)
+		hasher.Reset()
+	}
+
+	tree, err := NewMerkleTree(hashes[:4])
+	assert.NoError(t, err)
+	ab, err := hashMerkleNodes(hasher, multihash.SHA2_256, hashes[0], hashes[1])
+	assert.NoError(t, err)
+	cd, err := hashMerkleNodes(hasher, multihash.SHA2_256, hashes[2], hashes[3])
+	assert.NoError(t, err)
+
+	// An internal node does not pass as a leaf with a short proof
+	ok, err := VerifyMerkleProof(tree.Root(), ab, 0, 4, [][]byte{cd})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Nor with a nil padded proof
+	ok, err = VerifyMerkleProof(tree.Root(), ab, 0, 4, [][]byte{nil, cd})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = VerifyMerkleProof(tree.Root(), tree.Root(), 0, 4, nil)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Proofs longer than the tree are rejected
+	proof, err := tree.Proof(0)
+	assert.NoError(t, err)
+	ok, err = VerifyMerkleProof(tree.Root(), hashes[0], 0, 4, append(proof, nil))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// The promoted leaf of an odd tree needs a nil entry where it has no sibling
+	tree, err = NewMerkleTree(hashes)
+	assert.NoError(t, err)
+	proof, err = tree.Proof(4)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{nil, nil, tree.levels[2][0]}, proof)
+
+	ok, err = VerifyMerkleProof(tree.Root(), hashes[4], 4, 5, proof)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = VerifyMerkleProof(tree.Root(), hashes[4], 4, 5, proof[2:])
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = VerifyMerkleProof(tree.Root(), hashes[4], 4, 5, [][]byte{hashes[0], nil, tree.levels[2][0]})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = VerifyMerkleProof(tree.Root(), hashes[0], -1, 5, nil)
+	assert.ErrorIs(t, err, ErrMerkleIndexOutOfRange)
+}
+
+func TestMerkleRootWithCode(t *testing.T) {
+	values := []string{"the", "quick", "brown", "fox", "jumps", "over", "a", "lazy", "dog"}
+	var hashes [][]byte
+	for _, word := range values {
+		mh, _ := multihash.Sum([]byte(word), multihash.SHA2_256, -1)
+		hashes = append(hashes, mh)
+	}
+
+	leaves := append([][]byte{}, hashes...)
+	root, err := CalculateMerkleRootWithCode(leaves, multihash.SHA2_256)
+	assert.NoError(t, err)
+	assert.Equal(t, makeTestRoot("e24e552e0b6cf8835af179a14a766fb58c23e4ee1f7c6317d57ce39cc578cfac"), root)
+	assert.Equal(t, hashes, leaves)
+
+	for _, code := range []uint64{multihash.SHA3_256, multihash.KECCAK_256, multihash.BLAKE2B_MAX} {
+		root, err = CalculateMerkleRootWithCode(leaves, code)
+		assert.NoError(t, err)
+
+		mHash, err := multihash.Decode(root)
+		assert.NoError(t, err)
+		assert.Equal(t, code, mHash.Code)
+		assert.Equal(t, hashes, leaves)
+	}
+
+	root, err = CalculateMerkleRootWithCode([][]byte{}, multihash.SHA3_256)
+	assert.NoError(t, err)
+	expected, _ := multihash.Sum([]byte{}, multihash.SHA3_256, -1)
+	assert.Equal(t, []byte(expected), root)
+
+	mixed, _ := multihash.Sum([]byte("mixed"), multihash.SHA3_256, -1)
+	_, err = CalculateMerkleRootWithCode(append(leaves, mixed), multihash.SHA2_256)
+	assert.ErrorIs(t, err, ErrMixedMerkleLeafCodes)
+
+	_, err = CalculateMerkleRootWithCode(leaves, 0xffffff)
+	assert.Error(t, err)
+}
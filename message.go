@@ -0,0 +1,46 @@
+package util
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+)
+
+// MessageSignaturePrefix is prepended to messages before they are hashed and signed. The leading zero
+// byte can never start a canonically serialized protobuf message, so a message signature can never be
+// a valid transaction or block signature.
+const MessageSignaturePrefix = "\x00Koinos Signed Message:\n"
+
+// HashSignedMessage calculates the digest signed for the given off-chain message
+func HashSignedMessage(message []byte) []byte {
+	hasher := sha256.New()
+	hasher.Write([]byte(MessageSignaturePrefix))
+	hasher.Write([]byte(strconv.Itoa(len(message))))
+	hasher.Write(message)
+	return hasher.Sum(nil)
+}
+
+// SignMessage signs the given off-chain message, returning a compact signature
+func SignMessage(signer Signer, message []byte) ([]byte, error) {
+	return signer.SignDigest(HashSignedMessage(message))
+}
+
+// VerifyMessage recovers the address that signed the given off-chain message
+func VerifyMessage(message []byte, signature []byte) ([]byte, error) {
+	return RecoverAddress(HashSignedMessage(message), signature)
+}
+
+// VerifyMessageSigner verifies that the given off-chain message was signed by the given address
+func VerifyMessageSigner(message []byte, signature []byte, address []byte) error {
+	signer, err := VerifyMessage(message, signature)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(signer, address) {
+		return fmt.Errorf("%w: %s", ErrMissingSignature, DisplayAddress(address))
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignMessage(t *testing.T) {
+	key, err := GenerateKoinosKey()
+	assert.NoError(t, err)
+	otherKey, err := GenerateKoinosKey()
+	assert.NoError(t, err)
+
+	message := []byte("login challenge 1234")
+	signature, err := SignMessage(key, message)
+	assert.NoError(t, err)
+	assert.Len(t, signature, 65)
+
+	address, err := VerifyMessage(message, signature)
+	assert.NoError(t, err)
+	assert.Equal(t, key.AddressBytes(), address)
+
+	assert.NoError(t, VerifyMessageSigner(message, signature, key.AddressBytes()))
+	assert.ErrorIs(t, VerifyMessageSigner(message, signature, otherKey.AddressBytes()), ErrMissingSignature)
+	assert.ErrorIs(t, VerifyMessageSigner([]byte("login challenge 1235"), signature, key.AddressBytes()), ErrMissingSignature)
+
+	_, err = VerifyMessage(message, make([]byte, 65))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestMessageDomainSeparation(t *testing.T) {
+	key, err := GenerateKoinosKey()
+	assert.NoError(t, err)
+
+	builder := TransactionBuilder{Operations: makeTestOperations(), ChainID: makeTestChainID(), Nonce: 1, RCLimit: 100, Payer: key.AddressBytes()}
+	transaction, err := builder.Build()
+	assert.NoError(t, err)
+
+	id, err := multihash.Decode(transaction.Id)
+	assert.NoError(t, err)
+
+	// Signing the transaction ID as a message does not produce a valid transaction signature
+	signature, err := SignMessage(key, transaction.Id)
+	assert.NoError(t, err)
+	assert.NotEqual(t, id.Digest, HashSignedMessage(transaction.Id))
+
+	transaction.Signatures = [][]byte{signature}
+	assert.ErrorIs(t, VerifyTransactionSignatures(transaction, key.AddressBytes()), ErrMissingSignature)
+}
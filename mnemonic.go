@@ -0,0 +1,74 @@
+package util
+
+import (
+	"fmt"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+// KoinosDerivationPath is the default BIP44 derivation path of a Koinos account
+const KoinosDerivationPath = "m/44'/659'/0'/0/0"
+
+// GenerateMnemonic generates a new BIP39 mnemonic using the English wordlist.
+// The entropy size must be a multiple of 32 bits between 128 and 256 bits.
+func GenerateMnemonic(bitSize int) (string, error) {
+	entropy, err := bip39.NewEntropy(bitSize)
+	if err != nil {
+		return "", err
+	}
+
+	return bip39.NewMnemonic(entropy)
+}
+
+// NewMnemonicFromEntropy creates a BIP39 mnemonic from the given entropy
+func NewMnemonicFromEntropy(entropy []byte) (string, error) {
+	return bip39.NewMnemonic(entropy)
+}
+
+// ValidateMnemonic checks that the given mnemonic only contains words from the wordlist and has a valid checksum
+func ValidateMnemonic(mnemonic string) error {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return ErrInvalidMnemonic
+	}
+
+	return nil
+}
+
+// MnemonicToSeed creates the BIP39 seed of the given mnemonic and optional passphrase
+func MnemonicToSeed(mnemonic string, passphrase string) ([]byte, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+
+	return bip39.NewSeed(mnemonic, passphrase), nil
+}
+
+// NewKoinosKeyFromMnemonic derives the key at KoinosDerivationPath from the given mnemonic and optional passphrase
+func NewKoinosKeyFromMnemonic(mnemonic string, passphrase string) (*KoinosKey, error) {
+	return NewKoinosKeyFromMnemonicPath(mnemonic, passphrase, KoinosDerivationPath)
+}
+
+// NewKoinosKeyFromMnemonicPath derives the key at the given BIP32 path from the given mnemonic and optional passphrase.
+// The path must be absolute, starting with m/.
+func NewKoinosKeyFromMnemonicPath(mnemonic string, passphrase string, path string) (*KoinosKey, error) {
+	_, absolute, err := parseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !absolute {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDerivationPath, path)
+	}
+
+	master, err := NewHDKeyFromMnemonic(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := master.DerivePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return key.KoinosKey()
+}
@@ -0,0 +1,83 @@
+package util
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMnemonicVectors(t *testing.T) {
+	// Test vectors from the BIP39 specification, using the passphrase "TREZOR"
+	vectors := []struct {
+		entropy  string
+		mnemonic string
+		seed     string
+	}{
+		{
+			"00000000000000000000000000000000",
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			"legal winner thank year wave sausage worth useful legal winner thank yellow",
+			"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+			"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+			"dd48c104698c30cfe2b6142103248622fb7bb0ff692eebb00089b32d22484e1613912f0a5b694407be899ffd31ed3992c456cdf60f5d4564b8ba3f05a69890ad",
+		},
+	}
+
+	for _, v := range vectors {
+		entropy, err := hex.DecodeString(v.entropy)
+		assert.NoError(t, err)
+
+		mnemonic, err := NewMnemonicFromEntropy(entropy)
+		assert.NoError(t, err)
+		assert.Equal(t, v.mnemonic, mnemonic)
+
+		seed, err := MnemonicToSeed(mnemonic, "TREZOR")
+		assert.NoError(t, err)
+		assert.Equal(t, v.seed, hex.EncodeToString(seed))
+	}
+}
+
+func TestMnemonicKey(t *testing.T) {
+	mnemonic, err := GenerateMnemonic(128)
+	assert.NoError(t, err)
+	assert.Len(t, strings.Fields(mnemonic), 12)
+	assert.NoError(t, ValidateMnemonic(mnemonic))
+
+	mnemonic, err = GenerateMnemonic(256)
+	assert.NoError(t, err)
+	assert.Len(t, strings.Fields(mnemonic), 24)
+
+	_, err = GenerateMnemonic(100)
+	assert.Error(t, err)
+
+	key1, err := NewKoinosKeyFromMnemonic(mnemonic, "")
+	assert.NoError(t, err)
+	key2, err := NewKoinosKeyFromMnemonic(mnemonic, "")
+	assert.NoError(t, err)
+	assert.Equal(t, key1.PrivateBytes(), key2.PrivateBytes())
+
+	// A passphrase derives a different key
+	key3, err := NewKoinosKeyFromMnemonic(mnemonic, "passphrase")
+	assert.NoError(t, err)
+	assert.NotEqual(t, key1.PrivateBytes(), key3.PrivateBytes())
+
+	key4, err := NewKoinosKeyFromMnemonicPath(mnemonic, "", "m/44'/659'/1'/0/0")
+	assert.NoError(t, err)
+	assert.NotEqual(t, key1.PrivateBytes(), key4.PrivateBytes())
+
+	// Invalid checksum
+	_, err = NewKoinosKeyFromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", "")
+	assert.ErrorIs(t, err, ErrInvalidMnemonic)
+
+	_, err = NewKoinosKeyFromMnemonicPath(mnemonic, "", "44'/659'")
+	assert.ErrorIs(t, err, ErrInvalidDerivationPath)
+}
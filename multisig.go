@@ -0,0 +1,99 @@
+package util
+
+import (
+	"bytes"
+
+	kjson "github.com/koinos/koinos-proto-golang/v2/encoding/json"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	"google.golang.org/protobuf/proto"
+)
+
+// ExportTransaction encodes an unsigned or partially signed transaction as Koinos JSON,
+// so that it can be passed to co-signers
+func ExportTransaction(tx *protocol.Transaction) ([]byte, error) {
+	return kjson.Marshal(tx)
+}
+
+// ImportTransaction decodes a transaction exported with ExportTransaction and validates it
+func ImportTransaction(data []byte) (*protocol.Transaction, error) {
+	tx := &protocol.Transaction{}
+	if err := kjson.Unmarshal(data, tx); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// MergeTransactionSignatures merges the signatures of several copies of the same transaction.
+// Signatures are deduplicated by recovered signer, keeping the first signature of each signer.
+// The given transactions are not modified.
+func MergeTransactionSignatures(txs ...*protocol.Transaction) (*protocol.Transaction, error) {
+	if len(txs) == 0 {
+		return nil, ErrNoTransactions
+	}
+
+	merged := proto.Clone(txs[0]).(*protocol.Transaction)
+	merged.Signatures = nil
+
+	for _, tx := range txs {
+		if !bytes.Equal(tx.Id, merged.Id) {
+			return nil, ErrTransactionIDMismatch
+		}
+
+		merged.Signatures = append(merged.Signatures, tx.Signatures...)
+	}
+
+	if err := DeduplicateTransactionSignatures(merged); err != nil {
+		return nil, err
+	}
+
+	return merged, nil
+}
+
+// DeduplicateTransactionSignatures removes signatures from signers that have already signed the transaction
+func DeduplicateTransactionSignatures(tx *protocol.Transaction) error {
+	signers, err := RecoverTransactionSigners(tx)
+	if err != nil {
+		return err
+	}
+
+	signerSet := make(map[string]Void)
+	signatures := make([][]byte, 0, len(tx.Signatures))
+	for i, signer := range signers {
+		if _, ok := signerSet[string(signer)]; ok {
+			continue
+		}
+
+		signerSet[string(signer)] = Void{}
+		signatures = append(signatures, tx.Signatures[i])
+	}
+
+	tx.Signatures = signatures
+	return nil
+}
+
+// MissingTransactionSigners returns the required addresses that have not yet signed the transaction
+func MissingTransactionSigners(tx *protocol.Transaction, required ...[]byte) ([][]byte, error) {
+	signers, err := RecoverTransactionSigners(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	signerSet := make(map[string]Void)
+	for _, signer := range signers {
+		signerSet[string(signer)] = Void{}
+	}
+
+	var missing [][]byte
+	for _, address := range required {
+		if _, ok := signerSet[string(address)]; !ok {
+			missing = append(missing, address)
+		}
+	}
+
+	return missing, nil
+}
@@ -0,0 +1,71 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultisigWorkflow(t *testing.T) {
+	var keys []*KoinosKey
+	var addresses [][]byte
+	for i := 0; i < 3; i++ {
+		key, err := GenerateKoinosKey()
+		assert.NoError(t, err)
+		keys = append(keys, key)
+		addresses = append(addresses, key.AddressBytes())
+	}
+
+	builder := TransactionBuilder{Operations: makeTestOperations(), ChainID: makeTestChainID(), Nonce: 1, RCLimit: 100, Payer: addresses[0]}
+	unsigned, err := builder.Build()
+	assert.NoError(t, err)
+
+	data, err := ExportTransaction(unsigned)
+	assert.NoError(t, err)
+
+	// Each co-signer imports and signs their own copy
+	tx1, err := ImportTransaction(data)
+	assert.NoError(t, err)
+	assert.NoError(t, SignTransaction(keys[0].PrivateBytes(), tx1))
+
+	tx2, err := ImportTransaction(data)
+	assert.NoError(t, err)
+	assert.NoError(t, SignTransaction(keys[1].PrivateBytes(), tx2))
+	assert.NoError(t, SignTransaction(keys[0].PrivateBytes(), tx2))
+
+	missing, err := MissingTransactionSigners(tx1, addresses...)
+	assert.NoError(t, err)
+	assert.Equal(t, addresses[1:], missing)
+
+	merged, err := MergeTransactionSignatures(tx1, tx2)
+	assert.NoError(t, err)
+	assert.Len(t, merged.Signatures, 2)
+	assert.Len(t, tx1.Signatures, 1)
+	assert.Len(t, tx2.Signatures, 2)
+
+	missing, err = MissingTransactionSigners(merged, addresses...)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{addresses[2]}, missing)
+
+	// Round trip the partially signed transaction
+	data, err = ExportTransaction(merged)
+	assert.NoError(t, err)
+	tx3, err := ImportTransaction(data)
+	assert.NoError(t, err)
+	assert.NoError(t, SignTransaction(keys[2].PrivateBytes(), tx3))
+
+	merged, err = MergeTransactionSignatures(merged, tx3)
+	assert.NoError(t, err)
+	assert.Len(t, merged.Signatures, 3)
+	assert.NoError(t, VerifyTransactionSignatures(merged, addresses...))
+
+	// Transactions with different IDs cannot be merged
+	builder.Nonce++
+	other, err := builder.Build()
+	assert.NoError(t, err)
+	_, err = MergeTransactionSignatures(merged, other)
+	assert.ErrorIs(t, err, ErrTransactionIDMismatch)
+
+	_, err = MergeTransactionSignatures()
+	assert.ErrorIs(t, err, ErrNoTransactions)
+}
@@ -72,7 +72,13 @@ func HexStringToBytes(s string) ([]byte, error) {
 // Uses P2PKH (original bitcoin spec)
 // 33-35 alphanumeric characters beginning with the number 1, random digits, upper/lower case characters
 // exceptions: uppercase letter O, uppercase letter I, lowercase letter l, and the number 0
+// The address checksum is also verified
 func CheckIsValidAddress(s string) bool {
 	result, _ := regexp.MatchString("^[1][a-km-zA-HJ-NP-Z1-9]{32,34}$", s)
-	return result
+	if !result {
+		return false
+	}
+
+	_, err := ParseAddress(s)
+	return err == nil
 }
@@ -1,16 +1,112 @@
 package util
 
-import "math/rand"
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	mrand "math/rand"
+	"strings"
+	"sync"
+)
+
+// base58Alphabet is the base58 character set used by Koinos addresses and IDs
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// maxUnbiasedByte is the largest multiple of 58 that fits in a byte. Random bytes at or above
+// it are rejected so that every base58 character is equally likely.
+const maxUnbiasedByte = 256 - 256%58
+
+// ID is a random base58 identifier
+type ID string
+
+// ParseID parses and validates a base58 ID. If length is greater than zero, the ID must have exactly that length.
+func ParseID(s string, length int) (ID, error) {
+	id := ID(s)
+	if err := id.Validate(length); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// Validate checks that the ID is non-empty, only contains base58 characters and, if length is greater than zero,
+// has the given length
+func (id ID) Validate(length int) error {
+	if len(id) == 0 || (length > 0 && len(id) != length) {
+		return ErrInvalidID
+	}
+
+	for _, c := range id {
+		if !strings.ContainsRune(base58Alphabet, c) {
+			return ErrInvalidID
+		}
+	}
+
+	return nil
+}
+
+// String returns the ID as a string
+func (id ID) String() string {
+	return string(id)
+}
+
+// IDGenerator generates random base58 IDs from a source of random bytes
+type IDGenerator struct {
+	mu     sync.Mutex
+	source io.Reader
+}
+
+// NewIDGenerator creates an ID generator backed by crypto/rand
+func NewIDGenerator() *IDGenerator {
+	return &IDGenerator{source: rand.Reader}
+}
+
+// NewSeededIDGenerator creates a deterministic ID generator for reproducible tests.
+// It must not be used where IDs need to be unpredictable.
+func NewSeededIDGenerator(seed int64) *IDGenerator {
+	return &IDGenerator{source: mrand.New(mrand.NewSource(seed))}
+}
+
+// Generate generates an ID of the given length, which must be positive
+func (g *IDGenerator) Generate(length int) (ID, error) {
+	if length < 1 {
+		return "", fmt.Errorf("%w: length %d", ErrInvalidID, length)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	id := make([]byte, 0, length)
+	buf := make([]byte, length)
+
+	for len(id) < length {
+		if _, err := io.ReadFull(g.source, buf[:length-len(id)]); err != nil {
+			return "", err
+		}
+
+		for _, b := range buf[:length-len(id)] {
+			if b < maxUnbiasedByte {
+				id = append(id, base58Alphabet[b%58])
+			}
+		}
+	}
+
+	return ID(id), nil
+}
+
+// GenerateSecureBase58ID generates a random base58 ID using crypto/rand
+func GenerateSecureBase58ID(length int) (ID, error) {
+	return NewIDGenerator().Generate(length)
+}
 
 // GenerateBase58ID generates a random seed string
+//
+// Deprecated: the ID is generated with math/rand and is predictable. Use GenerateSecureBase58ID instead.
 func GenerateBase58ID(length int) string {
-	// Use the base-58 character set
-	var runes = []rune("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
-
 	// Randomly choose up to the given length
-	seed := make([]rune, length)
+	seed := make([]byte, length)
 	for i := 0; i < length; i++ {
-		seed[i] = runes[rand.Intn(len(runes))]
+		seed[i] = base58Alphabet[mrand.Intn(len(base58Alphabet))]
 	}
 
 	return string(seed)
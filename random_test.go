@@ -0,0 +1,68 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateID(t *testing.T) {
+	id, err := GenerateSecureBase58ID(32)
+	assert.NoError(t, err)
+	assert.Len(t, id, 32)
+	assert.NoError(t, id.Validate(32))
+
+	other, err := GenerateSecureBase58ID(32)
+	assert.NoError(t, err)
+	assert.NotEqual(t, id, other)
+
+	assert.Len(t, GenerateBase58ID(16), 16)
+
+	_, err = GenerateSecureBase58ID(0)
+	assert.ErrorIs(t, err, ErrInvalidID)
+
+	_, err = NewSeededIDGenerator(1).Generate(-1)
+	assert.ErrorIs(t, err, ErrInvalidID)
+
+	parsed, err := ParseID(id.String(), 32)
+	assert.NoError(t, err)
+	assert.Equal(t, id, parsed)
+
+	_, err = ParseID(id.String(), 31)
+	assert.ErrorIs(t, err, ErrInvalidID)
+
+	_, err = ParseID("abc0", 0)
+	assert.ErrorIs(t, err, ErrInvalidID)
+
+	_, err = ParseID("", 0)
+	assert.ErrorIs(t, err, ErrInvalidID)
+}
+
+func TestSeededIDGenerator(t *testing.T) {
+	id1, err := NewSeededIDGenerator(42).Generate(24)
+	assert.NoError(t, err)
+	id2, err := NewSeededIDGenerator(42).Generate(24)
+	assert.NoError(t, err)
+	assert.Equal(t, id1, id2)
+
+	id3, err := NewSeededIDGenerator(43).Generate(24)
+	assert.NoError(t, err)
+	assert.NotEqual(t, id1, id3)
+}
+
+func TestIDDistribution(t *testing.T) {
+	generator := NewSeededIDGenerator(1)
+	id, err := generator.Generate(58 * 1000)
+	assert.NoError(t, err)
+
+	counts := make(map[rune]int)
+	for _, c := range id {
+		counts[c]++
+	}
+
+	// Every character should appear roughly 1000 times
+	assert.Len(t, counts, 58)
+	for _, count := range counts {
+		assert.InDelta(t, 1000, count, 200)
+	}
+}
@@ -0,0 +1,306 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc/block_store"
+)
+
+// Default block stream options
+const (
+	DefaultBlockStreamBatchSize    = 100
+	DefaultBlockStreamPollInterval = time.Second
+)
+
+// BlockStreamOptions is the options for a block stream
+type BlockStreamOptions struct {
+	// StartHeight is the height of the first block to stream. Defaults to 1.
+	StartHeight uint64
+
+	// BatchSize is the maximum number of blocks fetched per request. Defaults to DefaultBlockStreamBatchSize.
+	BatchSize uint32
+
+	// Depth is the number of blocks the stream stays behind the head block
+	Depth uint64
+
+	// Irreversible restricts the stream to irreversible blocks, so that it never rolls back
+	Irreversible bool
+
+	// PollInterval is how long the stream waits before polling again once it has caught up.
+	// Defaults to DefaultBlockStreamPollInterval.
+	PollInterval time.Duration
+
+	// ReturnReceipt requests the block receipts along with the blocks
+	ReturnReceipt bool
+
+	// OnRollback is called for each streamed block that is no longer part of the chain, most recent
+	// first. Returning an error stops the stream.
+	OnRollback func(ctx context.Context, block *koinos.BlockTopology) error
+}
+
+// BlockStream yields the blocks of the chain in order, starting from a given height. When the head
+// block moves to a fork, the streamed blocks that are no longer part of the chain are rolled back
+// and the blocks of the new fork are streamed in their place.
+//
+// Forks are detected when the previous ID of the next block does not match the last streamed
+// block, or, once the stream has caught up, when the last streamed block is no longer an ancestor
+// of the head block.
+type BlockStream struct {
+	client  *KoinosRPCClient
+	options BlockStreamOptions
+
+	// Guards nextHeight writes and err
+	mutex sync.Mutex
+
+	nextHeight   uint64
+	irreversible uint64
+
+	// Streamed blocks that may still be rolled back
+	history []*koinos.BlockTopology
+
+	// Fetched blocks that have not been streamed yet
+	pending []*block_store.BlockItem
+
+	err error
+}
+
+// NewBlockStream creates a new block stream with the given options
+func NewBlockStream(client *KoinosRPCClient, options BlockStreamOptions) *BlockStream {
+	if options.StartHeight == 0 {
+		options.StartHeight = 1
+	}
+
+	if options.BatchSize == 0 {
+		options.BatchSize = DefaultBlockStreamBatchSize
+	}
+
+	if options.PollInterval == 0 {
+		options.PollInterval = DefaultBlockStreamPollInterval
+	}
+
+	return &BlockStream{client: client, options: options, nextHeight: options.StartHeight}
+}
+
+// Height returns the height of the next block to be streamed. It is safe to call while Blocks is running.
+func (s *BlockStream) Height() uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.nextHeight
+}
+
+// setHeight sets the height of the next block to be streamed
+func (s *BlockStream) setHeight(height uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.nextHeight = height
+}
+
+// Next returns the next block, waiting for it if the stream has caught up with the chain
+func (s *BlockStream) Next(ctx context.Context) (*block_store.BlockItem, error) {
+	for {
+		if len(s.pending) == 0 {
+			if err := s.fetch(ctx); err != nil {
+				// The rpc client does not wrap errors, so report cancellation explicitly
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				return nil, err
+			}
+			continue
+		}
+
+		item := s.pending[0]
+		s.pending = s.pending[1:]
+
+		if item.Block == nil || item.Block.Header == nil || item.BlockHeight != s.nextHeight {
+			return nil, fmt.Errorf("%w: expected block at height %d", ErrUnexpectedBlock, s.nextHeight)
+		}
+
+		// The chain has moved to a fork, rewind and fetch the blocks of the new fork
+		if len(s.history) > 0 && !bytes.Equal(item.Block.Header.Previous, s.history[len(s.history)-1].Id) {
+			s.pending = nil
+			if err := s.rollback(ctx); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		s.history = append(s.history, &koinos.BlockTopology{Id: item.BlockId, Height: item.BlockHeight, Previous: item.Block.Header.Previous})
+		s.setHeight(s.nextHeight + 1)
+		s.prune()
+
+		return item, nil
+	}
+}
+
+// Blocks streams blocks through the returned channel until the context is canceled or an error
+// occurs, after which the channel is closed and Err returns the error. The rollback callback is
+// called from the streaming goroutine.
+func (s *BlockStream) Blocks(ctx context.Context) <-chan *block_store.BlockItem {
+	blocks := make(chan *block_store.BlockItem)
+
+	go func() {
+		defer close(blocks)
+
+		for {
+			item, err := s.Next(ctx)
+			if err != nil {
+				s.setErr(err)
+				return
+			}
+
+			select {
+			case blocks <- item:
+			case <-ctx.Done():
+				s.setErr(ctx.Err())
+				return
+			}
+		}
+	}()
+
+	return blocks
+}
+
+// Err returns the error that stopped the block channel
+func (s *BlockStream) Err() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.err
+}
+
+func (s *BlockStream) setErr(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.err = err
+}
+
+// fetch fetches the next batch of blocks, waiting for the poll interval if none are available
+func (s *BlockStream) fetch(ctx context.Context) error {
+	headInfo, err := s.client.GetHeadInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	if headInfo.HeadTopology == nil {
+		return ErrInvalidHeadInfo
+	}
+
+	s.irreversible = headInfo.LastIrreversibleBlock
+
+	// Determine the highest block that may be streamed
+	var target uint64
+	if headInfo.HeadTopology.Height > s.options.Depth {
+		target = headInfo.HeadTopology.Height - s.options.Depth
+	}
+
+	if s.options.Irreversible && s.irreversible < target {
+		target = s.irreversible
+	}
+
+	if s.nextHeight > target {
+		// Check that the chain has not moved to a fork that is not yet longer than the streamed blocks
+		rolledBack, err := s.checkHead(ctx, headInfo.HeadTopology)
+		if err != nil || rolledBack {
+			return err
+		}
+
+		return s.wait(ctx)
+	}
+
+	numBlocks := uint64(s.options.BatchSize)
+	if target-s.nextHeight+1 < numBlocks {
+		numBlocks = target - s.nextHeight + 1
+	}
+
+	items, err := s.client.GetBlocksByHeight(ctx, headInfo.HeadTopology.Id, s.nextHeight, uint32(numBlocks), true, s.options.ReturnReceipt)
+	if err != nil {
+		return err
+	}
+
+	// The block store may lag behind the chain
+	if len(items) == 0 {
+		return s.wait(ctx)
+	}
+
+	s.pending = items
+	return nil
+}
+
+// rollback reverts the last streamed block
+func (s *BlockStream) rollback(ctx context.Context) error {
+	last := s.history[len(s.history)-1]
+	if last.Height <= s.irreversible {
+		return fmt.Errorf("%w: block at height %d", ErrRollbackTooDeep, last.Height)
+	}
+
+	s.history = s.history[:len(s.history)-1]
+	s.setHeight(last.Height)
+
+	if s.options.OnRollback != nil {
+		return s.options.OnRollback(ctx, last)
+	}
+
+	return nil
+}
+
+// checkHead rolls back the last streamed block if it is no longer an ancestor of the given head block
+func (s *BlockStream) checkHead(ctx context.Context, head *koinos.BlockTopology) (bool, error) {
+	if len(s.history) == 0 {
+		return false, nil
+	}
+
+	last := s.history[len(s.history)-1]
+
+	// A streamed block above the head can not be part of its chain
+	if last.Height <= head.Height {
+		id := head.Id
+		if last.Height < head.Height {
+			items, err := s.client.GetBlocksByHeight(ctx, head.Id, last.Height, 1, false, false)
+			if err != nil {
+				return false, err
+			}
+
+			// The block store may lag behind the chain
+			if len(items) == 0 {
+				return false, nil
+			}
+
+			id = items[0].BlockId
+		}
+
+		if bytes.Equal(id, last.Id) {
+			return false, nil
+		}
+	}
+
+	return true, s.rollback(ctx)
+}
+
+// prune forgets streamed blocks that can no longer be rolled back, keeping the last streamed block
+// to check the next block against
+func (s *BlockStream) prune() {
+	i := 0
+	for i < len(s.history)-1 && s.history[i].Height < s.irreversible {
+		i++
+	}
+
+	s.history = s.history[i:]
+}
+
+// wait waits for the poll interval or until the context is canceled
+func (s *BlockStream) wait(ctx context.Context) error {
+	timer := time.NewTimer(s.options.PollInterval)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,191 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc/block_store"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc/chain"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+// nextBlocks reads num blocks from the stream
+func nextBlocks(t *testing.T, stream *BlockStream, num int) []*block_store.BlockItem {
+	items := make([]*block_store.BlockItem, num)
+	for i := range items {
+		var err error
+		items[i], err = stream.Next(context.Background())
+		assert.NoError(t, err)
+	}
+	return items
+}
+
+func TestBlockStream(t *testing.T) {
+	server := newTestRPCServer()
+	defer server.Close()
+	chain := newTestChain(t, server)
+	blocks := chain.extend(nil, 10, 0)
+
+	stream := NewBlockStream(NewKoinosRPCClient(server.URL), BlockStreamOptions{StartHeight: 2, BatchSize: 3, PollInterval: time.Millisecond, ReturnReceipt: true})
+	items := nextBlocks(t, stream, 9)
+	for i, item := range items {
+		assert.True(t, proto.Equal(blocks[i+1], item.Block))
+		assert.Equal(t, blocks[i+1].Id, item.Receipt.Id)
+	}
+	assert.Equal(t, uint64(11), stream.Height())
+	assert.Equal(t, 3, server.callCount(GetBlocksByHeightCall))
+
+	// The stream waits for new blocks once it has caught up
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := stream.Next(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	blocks = append(blocks, chain.extend(blocks[9], 2, 0)...)
+	items = nextBlocks(t, stream, 2)
+	assert.Equal(t, blocks[10].Id, items[0].BlockId)
+	assert.Equal(t, blocks[11].Id, items[1].BlockId)
+}
+
+func TestBlockStreamDepth(t *testing.T) {
+	server := newTestRPCServer()
+	defer server.Close()
+	chain := newTestChain(t, server)
+	chain.extend(nil, 10, 0)
+	chain.setIrreversible(4)
+
+	client := NewKoinosRPCClient(server.URL)
+
+	stream := NewBlockStream(client, BlockStreamOptions{Depth: 3, PollInterval: time.Millisecond})
+	nextBlocks(t, stream, 7)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := stream.Next(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, uint64(8), stream.Height())
+
+	stream = NewBlockStream(client, BlockStreamOptions{Depth: 3, Irreversible: true, PollInterval: time.Millisecond})
+	nextBlocks(t, stream, 4)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = stream.Next(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, uint64(5), stream.Height())
+}
+
+func TestBlockStreamRollback(t *testing.T) {
+	server := newTestRPCServer()
+	defer server.Close()
+	chain := newTestChain(t, server)
+	blocks := chain.extend(nil, 5, 0)
+	chain.setIrreversible(2)
+
+	var rollbacks []*koinos.BlockTopology
+	options := BlockStreamOptions{
+		BatchSize:    2,
+		PollInterval: time.Millisecond,
+		OnRollback: func(ctx context.Context, block *koinos.BlockTopology) error {
+			rollbacks = append(rollbacks, block)
+			return nil
+		},
+	}
+
+	stream := NewBlockStream(NewKoinosRPCClient(server.URL), options)
+	nextBlocks(t, stream, 5)
+
+	// Switch to a longer fork from block 3
+	fork := chain.extend(blocks[2], 3, 1)
+	items := nextBlocks(t, stream, 3)
+	for i, item := range items {
+		assert.True(t, proto.Equal(fork[i], item.Block))
+	}
+
+	assert.Len(t, rollbacks, 2)
+	assert.Equal(t, blocks[4].Id, rollbacks[0].Id)
+	assert.Equal(t, blocks[3].Id, rollbacks[1].Id)
+	assert.Equal(t, uint64(4), rollbacks[1].Height)
+
+	// A fork past the irreversible block can not be rolled back
+	chain.setIrreversible(5)
+	chain.extend(blocks[3], 4, 2)
+	_, err := stream.Next(context.Background())
+	assert.ErrorIs(t, err, ErrRollbackTooDeep)
+}
+
+func TestBlockStreamSameHeightFork(t *testing.T) {
+	server := newTestRPCServer()
+	defer server.Close()
+	chain := newTestChain(t, server)
+	blocks := chain.extend(nil, 5, 0)
+
+	var rollbacks []*koinos.BlockTopology
+	options := BlockStreamOptions{
+		PollInterval: time.Millisecond,
+		OnRollback: func(ctx context.Context, block *koinos.BlockTopology) error {
+			rollbacks = append(rollbacks, block)
+			return nil
+		},
+	}
+
+	stream := NewBlockStream(NewKoinosRPCClient(server.URL), options)
+	nextBlocks(t, stream, 5)
+
+	// The head moves to a fork of the same height
+	fork := chain.extend(blocks[3], 1, 1)
+	items := nextBlocks(t, stream, 1)
+	assert.Equal(t, fork[0].Id, items[0].BlockId)
+	assert.Len(t, rollbacks, 1)
+	assert.Equal(t, blocks[4].Id, rollbacks[0].Id)
+
+	// Behind the head, the fork is detected from the ancestor at the last streamed height
+	rollbacks = nil
+	options.Depth = 2
+	stream = NewBlockStream(NewKoinosRPCClient(server.URL), options)
+	chain.extend(fork[0], 2, 0)
+	nextBlocks(t, stream, 5)
+
+	head := fork[0]
+	fork = chain.extend(blocks[3], 3, 2)
+	items = nextBlocks(t, stream, 1)
+	assert.Equal(t, fork[0].Id, items[0].BlockId)
+	assert.Len(t, rollbacks, 1)
+	assert.Equal(t, head.Id, rollbacks[0].Id)
+}
+
+func TestBlockStreamInvalidHeadInfo(t *testing.T) {
+	server := newTestRPCServer()
+	defer server.Close()
+	server.respond(GetHeadInfoCall, &chain.GetHeadInfoResponse{})
+
+	stream := NewBlockStream(NewKoinosRPCClient(server.URL), BlockStreamOptions{PollInterval: time.Millisecond})
+	_, err := stream.Next(context.Background())
+	assert.ErrorIs(t, err, ErrInvalidHeadInfo)
+}
+
+func TestBlockStreamChannel(t *testing.T) {
+	server := newTestRPCServer()
+	defer server.Close()
+	chain := newTestChain(t, server)
+	blocks := chain.extend(nil, 4, 0)
+
+	stream := NewBlockStream(NewKoinosRPCClient(server.URL), BlockStreamOptions{PollInterval: time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := stream.Blocks(ctx)
+	for _, block := range blocks {
+		item := <-ch
+		assert.True(t, bytes.Equal(block.Id, item.BlockId))
+		assert.True(t, stream.Height() > item.BlockHeight)
+	}
+
+	cancel()
+	for range ch {
+	}
+	assert.ErrorIs(t, stream.Err(), context.Canceled)
+}
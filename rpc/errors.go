@@ -0,0 +1,25 @@
+package rpc
+
+import (
+	"errors"
+)
+
+var (
+	// ErrUnexpectedBlock is the error returned when the block store returns a block other than the one requested
+	ErrUnexpectedBlock = errors.New("unexpected block")
+
+	// ErrInvalidHeadInfo is the error returned when a head info response has no head topology
+	ErrInvalidHeadInfo = errors.New("invalid head info")
+
+	// ErrRollbackTooDeep is the error returned when a fork would revert an irreversible block
+	ErrRollbackTooDeep = errors.New("rollback past irreversible block")
+
+	// ErrTransactionTimeout is the error returned when a transaction is not confirmed in time
+	ErrTransactionTimeout = errors.New("timed out waiting for transaction")
+
+	// ErrTransactionExpired is the error returned when a transaction can no longer be included in a block
+	ErrTransactionExpired = errors.New("transaction expired")
+
+	// ErrMissingReceipt is the error returned when a block receipt does not contain a transaction receipt
+	ErrMissingReceipt = errors.New("missing transaction receipt")
+)
@@ -3,18 +3,22 @@ package rpc
 import (
 	"bytes"
 	"context"
-	"crypto/sha256"
 	"encoding/json"
 
 	kjson "github.com/koinos/koinos-proto-golang/v2/encoding/json"
-	"github.com/koinos/koinos-proto-golang/v2/koinos/canonical"
+	"github.com/koinos/koinos-proto-golang/v2/koinos"
+	koinos_chain "github.com/koinos/koinos-proto-golang/v2/koinos/chain"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/contract_meta_store"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/contracts/token"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc/account_history"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc/block_store"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc/chain"
 	contract_meta_store_rpc "github.com/koinos/koinos-proto-golang/v2/koinos/rpc/contract_meta_store"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc/mempool"
+	transaction_store_rpc "github.com/koinos/koinos-proto-golang/v2/koinos/rpc/transaction_store"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/transaction_store"
 	util "github.com/koinos/koinos-util-golang/v2"
-	"github.com/multiformats/go-multihash"
 	jsonrpc "github.com/ybbus/jsonrpc/v3"
 	"google.golang.org/protobuf/proto"
 )
@@ -29,12 +33,47 @@ const (
 	GetContractMetaCall   = "contract_meta_store.get_contract_meta"
 )
 
+// These are the remaining chain rpc calls
+const (
+	SubmitBlockCall       = "chain.submit_block"
+	GetHeadInfoCall       = "chain.get_head_info"
+	GetForkHeadsCall      = "chain.get_fork_heads"
+	GetResourceLimitsCall = "chain.get_resource_limits"
+	InvokeSystemCallCall  = "chain.invoke_system_call"
+	ProposeBlockCall      = "chain.propose_block"
+)
+
+// These are the block store rpc calls
+const (
+	GetBlocksByIDCall     = "block_store.get_blocks_by_id"
+	GetBlocksByHeightCall = "block_store.get_blocks_by_height"
+	GetHighestBlockCall   = "block_store.get_highest_block"
+)
+
+// These are the mempool, transaction store and account history rpc calls
+const (
+	GetPendingTransactionsCall       = "mempool.get_pending_transactions"
+	CheckPendingAccountResourcesCall = "mempool.check_pending_account_resources"
+	GetTransactionsByIDCall          = "transaction_store.get_transactions_by_id"
+	GetAccountHistoryCall            = "account_history.get_account_history"
+)
+
 // SubmissionParams is the parameters for a transaction submission
 type SubmissionParams struct {
 	Nonce   uint64
 	RCLimit uint64
 }
 
+// AccountHistoryParams is the parameters for an account history query
+type AccountHistoryParams struct {
+	// SeqNum is the sequence number to start from. If nil, the query starts at the beginning or end
+	// of the history, depending on the direction.
+	SeqNum       *uint64
+	Limit        uint64
+	Ascending    bool
+	Irreversible bool
+}
+
 // KoinosRPCError is a golang error that also contains log messages from a reverted transaction
 type KoinosRPCError struct {
 	Logs    []string
@@ -198,14 +237,14 @@ func (c *KoinosRPCClient) GetContractMeta(ctx context.Context, contractID []byte
 }
 
 // SubmitTransaction creates and submits a transaction from a list of operations
-func (c *KoinosRPCClient) SubmitTransaction(ctx context.Context, ops []*protocol.Operation, key *util.KoinosKey, subParams *SubmissionParams, broadcast bool) (*protocol.TransactionReceipt, error) {
-	return c.SubmitTransactionWithPayer(ctx, ops, key, subParams, key.AddressBytes(), broadcast)
+func (c *KoinosRPCClient) SubmitTransaction(ctx context.Context, ops []*protocol.Operation, signer util.Signer, subParams *SubmissionParams, broadcast bool) (*protocol.TransactionReceipt, error) {
+	return c.SubmitTransactionWithPayer(ctx, ops, signer, subParams, signer.Address(), broadcast)
 }
 
 // SubmitTransaction creates and submits a transaction from a list of operations with a specified payer
-func (c *KoinosRPCClient) SubmitTransactionWithPayer(ctx context.Context, ops []*protocol.Operation, key *util.KoinosKey, subParams *SubmissionParams, payer []byte, broadcast bool) (*protocol.TransactionReceipt, error) {
+func (c *KoinosRPCClient) SubmitTransactionWithPayer(ctx context.Context, ops []*protocol.Operation, signer util.Signer, subParams *SubmissionParams, payer []byte, broadcast bool) (*protocol.TransactionReceipt, error) {
 	// Cache the public address
-	address := key.AddressBytes()
+	address := signer.Address()
 
 	var err error
 	var nonce uint64 = 0
@@ -225,12 +264,6 @@ func (c *KoinosRPCClient) SubmitTransactionWithPayer(ctx context.Context, ops []
 		nonce++
 	}
 
-	// Convert nonce to bytes
-	nonceBytes, err := util.UInt64ToNonceBytes(nonce)
-	if err != nil {
-		return nil, err
-	}
-
 	// If the rc limit is not provided, get it from the chain
 	if rcLimit == 0 {
 		rcLimit, err = c.GetAccountRc(ctx, address)
@@ -239,83 +272,309 @@ func (c *KoinosRPCClient) SubmitTransactionWithPayer(ctx context.Context, ops []
 		}
 	}
 
-	// Get operation multihashes
-	opHashes := make([][]byte, len(ops))
-	for i, op := range ops {
-		opHashes[i], err = util.HashMessage(op)
-		if err != nil {
-			return nil, err
-		}
+	chainID, err := c.GetChainID(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// Find merkle root
-	merkleRoot, err := util.CalculateMerkleRoot(opHashes)
+	// Create the transaction
+	builder := util.TransactionBuilder{Operations: ops, ChainID: chainID, Nonce: nonce, RCLimit: rcLimit, Payer: payer}
+	if !bytes.Equal(payer, address) {
+		builder.Payee = address
+	}
+
+	// Sign the transaction
+	transaction, err := builder.BuildAndSign(signer)
 	if err != nil {
 		return nil, err
 	}
 
-	chainID, err := c.GetChainID(ctx)
+	// Submit the transaction
+	return c.SubmitSignedTransaction(ctx, transaction, broadcast)
+}
+
+// SubmitSignedTransaction submits an already built and signed transaction
+func (c *KoinosRPCClient) SubmitSignedTransaction(ctx context.Context, transaction *protocol.Transaction, broadcast bool) (*protocol.TransactionReceipt, error) {
+	params := chain.SubmitTransactionRequest{}
+	params.Transaction = transaction
+	params.Broadcast = broadcast
+
+	// Make the rpc call
+	var cResp chain.SubmitTransactionResponse
+	err := c.Call(ctx, SubmitTransactionCall, &params, &cResp)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create the header
-	var header protocol.TransactionHeader
-	if bytes.Equal(payer, address) {
-		header = protocol.TransactionHeader{ChainId: chainID, RcLimit: rcLimit, Nonce: nonceBytes, OperationMerkleRoot: merkleRoot, Payer: payer}
-	} else {
-		header = protocol.TransactionHeader{ChainId: chainID, RcLimit: rcLimit, Nonce: nonceBytes, OperationMerkleRoot: merkleRoot, Payer: payer, Payee: address}
+	return cResp.Receipt, nil
+}
+
+// GetChainID gets the chain id
+func (c *KoinosRPCClient) GetChainID(ctx context.Context) ([]byte, error) {
+	// Build the contract request
+	params := chain.GetChainIdRequest{}
+
+	// Make the rpc call
+	var cResp chain.GetChainIdResponse
+	err := c.Call(ctx, GetChainIDCall, &params, &cResp)
+	if err != nil {
+		return nil, err
 	}
 
-	headerBytes, err := canonical.Marshal(&header)
+	return cResp.ChainId, nil
+}
+
+// SubmitBlock submits a block to the chain
+func (c *KoinosRPCClient) SubmitBlock(ctx context.Context, block *protocol.Block) (*protocol.BlockReceipt, error) {
+	// Build the contract request
+	params := chain.SubmitBlockRequest{
+		Block: block,
+	}
+
+	// Make the rpc call
+	var cResp chain.SubmitBlockResponse
+	err := c.Call(ctx, SubmitBlockCall, &params, &cResp)
 	if err != nil {
 		return nil, err
 	}
 
-	// Calculate the transaction ID
-	sha256Hasher := sha256.New()
-	sha256Hasher.Write(headerBytes)
-	tid, err := multihash.Encode(sha256Hasher.Sum(nil), multihash.SHA2_256)
+	return cResp.Receipt, nil
+}
+
+// GetHeadInfo gets the head block topology, last irreversible block and head state
+func (c *KoinosRPCClient) GetHeadInfo(ctx context.Context) (*chain.GetHeadInfoResponse, error) {
+	// Build the contract request
+	params := chain.GetHeadInfoRequest{}
+
+	// Make the rpc call
+	var cResp chain.GetHeadInfoResponse
+	err := c.Call(ctx, GetHeadInfoCall, &params, &cResp)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create the transaction
-	transaction := protocol.Transaction{Header: &header, Operations: ops, Id: tid}
+	return &cResp, nil
+}
 
-	// Sign the transaction
-	err = util.SignTransaction(key.PrivateBytes(), &transaction)
+// GetForkHeads gets the last irreversible block and the heads of all forks
+func (c *KoinosRPCClient) GetForkHeads(ctx context.Context) (*chain.GetForkHeadsResponse, error) {
+	// Build the contract request
+	params := chain.GetForkHeadsRequest{}
 
+	// Make the rpc call
+	var cResp chain.GetForkHeadsResponse
+	err := c.Call(ctx, GetForkHeadsCall, &params, &cResp)
 	if err != nil {
 		return nil, err
 	}
 
-	// Submit the transaction
-	params := chain.SubmitTransactionRequest{}
-	params.Transaction = &transaction
-	params.Broadcast = broadcast
+	return &cResp, nil
+}
+
+// GetResourceLimits gets the current resource limits and costs of the chain
+func (c *KoinosRPCClient) GetResourceLimits(ctx context.Context) (*koinos_chain.ResourceLimitData, error) {
+	// Build the contract request
+	params := chain.GetResourceLimitsRequest{}
 
 	// Make the rpc call
-	var cResp chain.SubmitTransactionResponse
-	err = c.Call(ctx, SubmitTransactionCall, &params, &cResp)
+	var cResp chain.GetResourceLimitsResponse
+	err := c.Call(ctx, GetResourceLimitsCall, &params, &cResp)
 	if err != nil {
 		return nil, err
 	}
 
-	return cResp.Receipt, nil
+	return cResp.ResourceLimitData, nil
 }
 
-// GetChainID gets the chain id
-func (c *KoinosRPCClient) GetChainID(ctx context.Context) ([]byte, error) {
+// InvokeSystemCall invokes the system call with the given ID and returns its value
+func (c *KoinosRPCClient) InvokeSystemCall(ctx context.Context, id int64, args []byte, callerData *koinos_chain.CallerData) ([]byte, error) {
 	// Build the contract request
-	params := chain.GetChainIdRequest{}
+	params := chain.InvokeSystemCallRequest{
+		SystemCall: &chain.InvokeSystemCallRequest_Id{Id: id},
+		Args:       args,
+		CallerData: callerData,
+	}
+
+	return c.invokeSystemCall(ctx, &params)
+}
+
+// InvokeSystemCallByName invokes the system call with the given name and returns its value
+func (c *KoinosRPCClient) InvokeSystemCallByName(ctx context.Context, name string, args []byte, callerData *koinos_chain.CallerData) ([]byte, error) {
+	// Build the contract request
+	params := chain.InvokeSystemCallRequest{
+		SystemCall: &chain.InvokeSystemCallRequest_Name{Name: name},
+		Args:       args,
+		CallerData: callerData,
+	}
+
+	return c.invokeSystemCall(ctx, &params)
+}
 
+// invokeSystemCall makes the invoke system call rpc call
+func (c *KoinosRPCClient) invokeSystemCall(ctx context.Context, params *chain.InvokeSystemCallRequest) ([]byte, error) {
 	// Make the rpc call
-	var cResp chain.GetChainIdResponse
-	err := c.Call(ctx, GetChainIDCall, &params, &cResp)
+	var cResp chain.InvokeSystemCallResponse
+	err := c.Call(ctx, InvokeSystemCallCall, params, &cResp)
 	if err != nil {
 		return nil, err
 	}
 
-	return cResp.ChainId, nil
+	return cResp.Value, nil
+}
+
+// ProposeBlock proposes a block to the chain, returning the receipt and the indices of failed transactions
+func (c *KoinosRPCClient) ProposeBlock(ctx context.Context, block *protocol.Block) (*chain.ProposeBlockResponse, error) {
+	// Build the contract request
+	params := chain.ProposeBlockRequest{
+		Block: block,
+	}
+
+	// Make the rpc call
+	var cResp chain.ProposeBlockResponse
+	err := c.Call(ctx, ProposeBlockCall, &params, &cResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cResp, nil
+}
+
+// GetBlocksByID gets the blocks with the given IDs from the block store. The block and receipt of
+// each item are only populated when requested.
+func (c *KoinosRPCClient) GetBlocksByID(ctx context.Context, blockIDs [][]byte, returnBlock bool, returnReceipt bool) ([]*block_store.BlockItem, error) {
+	// Build the contract request
+	params := block_store.GetBlocksByIdRequest{
+		BlockIds:      blockIDs,
+		ReturnBlock:   returnBlock,
+		ReturnReceipt: returnReceipt,
+	}
+
+	// Make the rpc call
+	var cResp block_store.GetBlocksByIdResponse
+	err := c.Call(ctx, GetBlocksByIDCall, &params, &cResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return cResp.BlockItems, nil
+}
+
+// GetBlocksByHeight gets up to numBlocks blocks starting at the given height from the block store.
+// The blocks are ancestors of the given head block ID. The block and receipt of each item are only
+// populated when requested.
+func (c *KoinosRPCClient) GetBlocksByHeight(ctx context.Context, headBlockID []byte, startHeight uint64, numBlocks uint32, returnBlock bool, returnReceipt bool) ([]*block_store.BlockItem, error) {
+	// Build the contract request
+	params := block_store.GetBlocksByHeightRequest{
+		HeadBlockId:         headBlockID,
+		AncestorStartHeight: startHeight,
+		NumBlocks:           numBlocks,
+		ReturnBlock:         returnBlock,
+		ReturnReceipt:       returnReceipt,
+	}
+
+	// Make the rpc call
+	var cResp block_store.GetBlocksByHeightResponse
+	err := c.Call(ctx, GetBlocksByHeightCall, &params, &cResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return cResp.BlockItems, nil
+}
+
+// GetHighestBlock gets the topology of the highest block in the block store
+func (c *KoinosRPCClient) GetHighestBlock(ctx context.Context) (*koinos.BlockTopology, error) {
+	// Build the contract request
+	params := block_store.GetHighestBlockRequest{}
+
+	// Make the rpc call
+	var cResp block_store.GetHighestBlockResponse
+	err := c.Call(ctx, GetHighestBlockCall, &params, &cResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return cResp.Topology, nil
+}
+
+// GetPendingTransactions gets up to limit pending transactions from the mempool. If blockID is nil,
+// the pending transactions on top of the head block are returned.
+func (c *KoinosRPCClient) GetPendingTransactions(ctx context.Context, limit uint64, blockID []byte) ([]*mempool.PendingTransaction, error) {
+	// Build the contract request
+	params := mempool.GetPendingTransactionsRequest{
+		Limit:   limit,
+		BlockId: blockID,
+	}
+
+	// Make the rpc call
+	var cResp mempool.GetPendingTransactionsResponse
+	err := c.Call(ctx, GetPendingTransactionsCall, &params, &cResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return cResp.PendingTransactions, nil
+}
+
+// CheckPendingAccountResources checks whether the payer can afford the given rc limit on top of its
+// pending transactions in the mempool. If blockID is nil, the check is made against the head block.
+func (c *KoinosRPCClient) CheckPendingAccountResources(ctx context.Context, payer []byte, maxPayerRC uint64, rcLimit uint64, blockID []byte) (bool, error) {
+	// Build the contract request
+	params := mempool.CheckPendingAccountResourcesRequest{
+		Payer:      payer,
+		MaxPayerRc: maxPayerRC,
+		RcLimit:    rcLimit,
+		BlockId:    blockID,
+	}
+
+	// Make the rpc call
+	var cResp mempool.CheckPendingAccountResourcesResponse
+	err := c.Call(ctx, CheckPendingAccountResourcesCall, &params, &cResp)
+	if err != nil {
+		return false, err
+	}
+
+	return cResp.Success, nil
+}
+
+// GetTransactionsByID gets the transactions with the given IDs from the transaction store, along
+// with the IDs of the blocks that contain them
+func (c *KoinosRPCClient) GetTransactionsByID(ctx context.Context, transactionIDs [][]byte) ([]*transaction_store.TransactionItem, error) {
+	// Build the contract request
+	params := transaction_store_rpc.GetTransactionsByIdRequest{
+		TransactionIds: transactionIDs,
+	}
+
+	// Make the rpc call
+	var cResp transaction_store_rpc.GetTransactionsByIdResponse
+	err := c.Call(ctx, GetTransactionsByIDCall, &params, &cResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return cResp.Transactions, nil
+}
+
+// GetAccountHistory gets the transaction and block history of a given account
+func (c *KoinosRPCClient) GetAccountHistory(ctx context.Context, address []byte, historyParams *AccountHistoryParams) ([]*account_history.AccountHistoryEntry, error) {
+	// Build the contract request
+	params := account_history.GetAccountHistoryRequest{
+		Address: address,
+	}
+
+	if historyParams != nil {
+		params.SeqNum = historyParams.SeqNum
+		params.Limit = historyParams.Limit
+		params.Ascending = historyParams.Ascending
+		params.Irreversible = historyParams.Irreversible
+	}
+
+	// Make the rpc call
+	var cResp account_history.GetAccountHistoryResponse
+	err := c.Call(ctx, GetAccountHistoryCall, &params, &cResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return cResp.Values, nil
 }
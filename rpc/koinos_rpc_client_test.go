@@ -0,0 +1,439 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	kjson "github.com/koinos/koinos-proto-golang/v2/encoding/json"
+	"github.com/koinos/koinos-proto-golang/v2/koinos"
+	koinos_account_history "github.com/koinos/koinos-proto-golang/v2/koinos/account_history"
+	koinos_chain "github.com/koinos/koinos-proto-golang/v2/koinos/chain"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc/account_history"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc/block_store"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc/chain"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc/mempool"
+	transaction_store_rpc "github.com/koinos/koinos-proto-golang/v2/koinos/rpc/transaction_store"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/transaction_store"
+	util "github.com/koinos/koinos-util-golang/v2"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+// testHandler handles the raw params of a single rpc method
+type testHandler func(params json.RawMessage) (proto.Message, error)
+
+// testRPCServer is a local stand-in for a Koinos JSON-RPC node
+type testRPCServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	handlers map[string]testHandler
+	calls    map[string]int
+}
+
+func newTestRPCServer() *testRPCServer {
+	s := &testRPCServer{handlers: make(map[string]testHandler), calls: make(map[string]int)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// handle registers the handler of the given method
+func (s *testRPCServer) handle(method string, handler testHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method] = handler
+}
+
+// respond registers a handler that always returns the given message
+func (s *testRPCServer) respond(method string, resp proto.Message) {
+	s.handle(method, func(json.RawMessage) (proto.Message, error) {
+		return resp, nil
+	})
+}
+
+// callCount returns the number of times the given method was called
+func (s *testRPCServer) callCount(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
+func (s *testRPCServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     interface{}     `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	handler, ok := s.handlers[req.Method]
+	s.calls[req.Method]++
+	s.mu.Unlock()
+
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	if !ok {
+		resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+	} else if result, err := handler(req.Params); err != nil {
+		resp["error"] = map[string]interface{}{"code": -32603, "message": err.Error()}
+	} else {
+		data, err := kjson.Marshal(result)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		resp["result"] = json.RawMessage(data)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// unmarshalParams decodes the raw params of a request
+func unmarshalParams(t *testing.T, params json.RawMessage, req proto.Message) {
+	assert.NoError(t, kjson.Unmarshal(params, req))
+}
+
+func TestChainRPC(t *testing.T) {
+	server := newTestRPCServer()
+	defer server.Close()
+	client := NewKoinosRPCClient(server.URL)
+	ctx := context.Background()
+
+	headInfo := &chain.GetHeadInfoResponse{
+		HeadTopology:          &koinos.BlockTopology{Id: []byte{0x12, 0x20, 0x01}, Height: 100, Previous: []byte{0x12, 0x20, 0x02}},
+		LastIrreversibleBlock: 40,
+		HeadBlockTime:         12345,
+	}
+	server.respond(GetHeadInfoCall, headInfo)
+
+	info, err := client.GetHeadInfo(ctx)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(headInfo, info))
+
+	forkHeads := &chain.GetForkHeadsResponse{
+		LastIrreversibleBlock: &koinos.BlockTopology{Height: 40},
+		ForkHeads:             []*koinos.BlockTopology{headInfo.HeadTopology, {Height: 99}},
+	}
+	server.respond(GetForkHeadsCall, forkHeads)
+
+	heads, err := client.GetForkHeads(ctx)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(forkHeads, heads))
+
+	limits := &koinos_chain.ResourceLimitData{DiskStorageLimit: 1, DiskStorageCost: 2, NetworkBandwidthLimit: 3, NetworkBandwidthCost: 4, ComputeBandwidthLimit: 5, ComputeBandwidthCost: 6}
+	server.respond(GetResourceLimitsCall, &chain.GetResourceLimitsResponse{ResourceLimitData: limits})
+
+	resourceLimits, err := client.GetResourceLimits(ctx)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(limits, resourceLimits))
+
+	server.handle(InvokeSystemCallCall, func(params json.RawMessage) (proto.Message, error) {
+		var req chain.InvokeSystemCallRequest
+		unmarshalParams(t, params, &req)
+
+		switch x := req.SystemCall.(type) {
+		case *chain.InvokeSystemCallRequest_Id:
+			return &chain.InvokeSystemCallResponse{Value: append([]byte{byte(x.Id)}, req.Args...)}, nil
+		case *chain.InvokeSystemCallRequest_Name:
+			return &chain.InvokeSystemCallResponse{Value: append([]byte(x.Name), req.Args...)}, nil
+		}
+
+		return nil, assert.AnError
+	})
+
+	value, err := client.InvokeSystemCall(ctx, 7, []byte{0x01}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x07, 0x01}, value)
+
+	value, err = client.InvokeSystemCallByName(ctx, "get_head_info", []byte{0x01}, &koinos_chain.CallerData{Caller: []byte{0x02}})
+	assert.NoError(t, err)
+	assert.Equal(t, append([]byte("get_head_info"), 0x01), value)
+
+	server.respond(GetChainIDCall, &chain.GetChainIdResponse{ChainId: []byte{0x12, 0x20, 0x03}})
+	chainID, err := client.GetChainID(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x12, 0x20, 0x03}, chainID)
+}
+
+func TestChainRPCBlocks(t *testing.T) {
+	server := newTestRPCServer()
+	defer server.Close()
+	client := NewKoinosRPCClient(server.URL)
+	ctx := context.Background()
+
+	key, err := util.GenerateKoinosKey()
+	assert.NoError(t, err)
+	block, err := util.BuildBlock(&protocol.BlockHeader{Height: 1, Timestamp: 1000}, nil, key)
+	assert.NoError(t, err)
+
+	server.handle(SubmitBlockCall, func(params json.RawMessage) (proto.Message, error) {
+		var req chain.SubmitBlockRequest
+		unmarshalParams(t, params, &req)
+		assert.True(t, proto.Equal(block, req.Block))
+		return &chain.SubmitBlockResponse{Receipt: &protocol.BlockReceipt{Id: req.Block.Id, Height: req.Block.Header.Height}}, nil
+	})
+
+	receipt, err := client.SubmitBlock(ctx, block)
+	assert.NoError(t, err)
+	assert.Equal(t, block.Id, receipt.Id)
+	assert.Equal(t, uint64(1), receipt.Height)
+
+	server.respond(ProposeBlockCall, &chain.ProposeBlockResponse{FailedTransactionIndices: []uint32{1, 3}})
+	proposal, err := client.ProposeBlock(ctx, block)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1, 3}, proposal.FailedTransactionIndices)
+}
+
+func TestBlockStoreRPC(t *testing.T) {
+	server := newTestRPCServer()
+	defer server.Close()
+	client := NewKoinosRPCClient(server.URL)
+	ctx := context.Background()
+
+	key, err := util.GenerateKoinosKey()
+	assert.NoError(t, err)
+
+	blocks := make([]*protocol.Block, 5)
+	for i := range blocks {
+		header := &protocol.BlockHeader{Height: uint64(i + 1), Timestamp: uint64(i * 1000)}
+		if i > 0 {
+			header.Previous = blocks[i-1].Id
+		}
+		blocks[i], err = util.BuildBlock(header, nil, key)
+		assert.NoError(t, err)
+	}
+
+	// makeItem creates the block store item of a block, honoring the return options
+	makeItem := func(block *protocol.Block, returnBlock bool, returnReceipt bool) *block_store.BlockItem {
+		item := &block_store.BlockItem{BlockId: block.Id, BlockHeight: block.Header.Height}
+		if returnBlock {
+			item.Block = block
+		}
+		if returnReceipt {
+			item.Receipt = &protocol.BlockReceipt{Id: block.Id, Height: block.Header.Height}
+		}
+		return item
+	}
+
+	server.handle(GetBlocksByIDCall, func(params json.RawMessage) (proto.Message, error) {
+		var req block_store.GetBlocksByIdRequest
+		unmarshalParams(t, params, &req)
+
+		resp := &block_store.GetBlocksByIdResponse{}
+		for _, id := range req.BlockIds {
+			for _, block := range blocks {
+				if bytes.Equal(block.Id, id) {
+					resp.BlockItems = append(resp.BlockItems, makeItem(block, req.ReturnBlock, req.ReturnReceipt))
+				}
+			}
+		}
+		return resp, nil
+	})
+
+	server.handle(GetBlocksByHeightCall, func(params json.RawMessage) (proto.Message, error) {
+		var req block_store.GetBlocksByHeightRequest
+		unmarshalParams(t, params, &req)
+		assert.Equal(t, blocks[len(blocks)-1].Id, req.HeadBlockId)
+
+		resp := &block_store.GetBlocksByHeightResponse{}
+		for _, block := range blocks {
+			if block.Header.Height >= req.AncestorStartHeight && block.Header.Height < req.AncestorStartHeight+uint64(req.NumBlocks) {
+				resp.BlockItems = append(resp.BlockItems, makeItem(block, req.ReturnBlock, req.ReturnReceipt))
+			}
+		}
+		return resp, nil
+	})
+
+	head := blocks[len(blocks)-1]
+	server.respond(GetHighestBlockCall, &block_store.GetHighestBlockResponse{Topology: &koinos.BlockTopology{Id: head.Id, Height: head.Header.Height, Previous: head.Header.Previous}})
+
+	topology, err := client.GetHighestBlock(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, head.Id, topology.Id)
+	assert.Equal(t, uint64(5), topology.Height)
+
+	items, err := client.GetBlocksByID(ctx, [][]byte{blocks[1].Id, blocks[3].Id}, true, false)
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.True(t, proto.Equal(blocks[1], items[0].Block))
+	assert.True(t, proto.Equal(blocks[3], items[1].Block))
+	assert.Nil(t, items[0].Receipt)
+	assert.NoError(t, util.ValidateBlock(items[0].Block))
+
+	items, err = client.GetBlocksByHeight(ctx, topology.Id, 2, 3, false, true)
+	assert.NoError(t, err)
+	assert.Len(t, items, 3)
+	for i, item := range items {
+		assert.Equal(t, uint64(i+2), item.BlockHeight)
+		assert.Equal(t, blocks[i+1].Id, item.Receipt.Id)
+		assert.Nil(t, item.Block)
+	}
+
+	items, err = client.GetBlocksByID(ctx, [][]byte{{0x00}}, true, true)
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestAccountActivityRPC(t *testing.T) {
+	server := newTestRPCServer()
+	defer server.Close()
+	client := NewKoinosRPCClient(server.URL)
+	ctx := context.Background()
+
+	key, err := util.GenerateKoinosKey()
+	assert.NoError(t, err)
+
+	transactions := make([]*protocol.Transaction, 3)
+	for i := range transactions {
+		builder := util.TransactionBuilder{ChainID: []byte{0x12, 0x20, 0x03}, Nonce: uint64(i + 1), RCLimit: 100, Payer: key.AddressBytes()}
+		transactions[i], err = builder.BuildAndSign(key)
+		assert.NoError(t, err)
+	}
+
+	server.handle(GetPendingTransactionsCall, func(params json.RawMessage) (proto.Message, error) {
+		var req mempool.GetPendingTransactionsRequest
+		unmarshalParams(t, params, &req)
+		assert.Nil(t, req.BlockId)
+
+		resp := &mempool.GetPendingTransactionsResponse{}
+		for i := uint64(0); i < req.Limit && i < uint64(len(transactions)); i++ {
+			resp.PendingTransactions = append(resp.PendingTransactions, &mempool.PendingTransaction{Transaction: transactions[i], DiskStorageUsed: 10})
+		}
+		return resp, nil
+	})
+
+	pending, err := client.GetPendingTransactions(ctx, 2, nil)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 2)
+	assert.True(t, proto.Equal(transactions[1], pending[1].Transaction))
+	assert.Equal(t, uint64(10), pending[0].DiskStorageUsed)
+
+	server.handle(CheckPendingAccountResourcesCall, func(params json.RawMessage) (proto.Message, error) {
+		var req mempool.CheckPendingAccountResourcesRequest
+		unmarshalParams(t, params, &req)
+		assert.Equal(t, key.AddressBytes(), req.Payer)
+		assert.Equal(t, []byte{0x01}, req.BlockId)
+		return &mempool.CheckPendingAccountResourcesResponse{Success: req.RcLimit <= req.MaxPayerRc-300}, nil
+	})
+
+	ok, err := client.CheckPendingAccountResources(ctx, key.AddressBytes(), 1000, 700, []byte{0x01})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = client.CheckPendingAccountResources(ctx, key.AddressBytes(), 1000, 701, []byte{0x01})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	server.handle(GetTransactionsByIDCall, func(params json.RawMessage) (proto.Message, error) {
+		var req transaction_store_rpc.GetTransactionsByIdRequest
+		unmarshalParams(t, params, &req)
+
+		resp := &transaction_store_rpc.GetTransactionsByIdResponse{}
+		for _, id := range req.TransactionIds {
+			for _, transaction := range transactions {
+				if bytes.Equal(transaction.Id, id) {
+					resp.Transactions = append(resp.Transactions, &transaction_store.TransactionItem{Transaction: transaction, ContainingBlocks: [][]byte{{0x02}}})
+				}
+			}
+		}
+		return resp, nil
+	})
+
+	items, err := client.GetTransactionsByID(ctx, [][]byte{transactions[2].Id})
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.True(t, proto.Equal(transactions[2], items[0].Transaction))
+	assert.Equal(t, [][]byte{{0x02}}, items[0].ContainingBlocks)
+
+	server.handle(GetAccountHistoryCall, func(params json.RawMessage) (proto.Message, error) {
+		var req account_history.GetAccountHistoryRequest
+		unmarshalParams(t, params, &req)
+		assert.Equal(t, key.AddressBytes(), req.Address)
+
+		resp := &account_history.GetAccountHistoryResponse{}
+		if req.SeqNum == nil {
+			resp.Values = append(resp.Values, &account_history.AccountHistoryEntry{
+				SeqNum: 0,
+				Record: &account_history.AccountHistoryEntry_Block{Block: &koinos_account_history.BlockRecord{Header: &protocol.BlockHeader{Height: 1}}},
+			})
+		}
+		for i, transaction := range transactions {
+			if uint64(len(resp.Values)) == req.Limit {
+				break
+			}
+			if req.SeqNum == nil || uint64(i+1) >= *req.SeqNum {
+				resp.Values = append(resp.Values, &account_history.AccountHistoryEntry{
+					SeqNum: uint64(i + 1),
+					Record: &account_history.AccountHistoryEntry_Trx{Trx: &koinos_account_history.TransactionRecord{Transaction: transaction}},
+				})
+			}
+		}
+		return resp, nil
+	})
+
+	history, err := client.GetAccountHistory(ctx, key.AddressBytes(), &AccountHistoryParams{Limit: 2, Ascending: true})
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, uint64(1), history[0].GetBlock().Header.Height)
+	assert.True(t, proto.Equal(transactions[0], history[1].GetTrx().Transaction))
+
+	seqNum := uint64(2)
+	history, err = client.GetAccountHistory(ctx, key.AddressBytes(), &AccountHistoryParams{SeqNum: &seqNum, Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, uint64(2), history[0].SeqNum)
+	assert.True(t, proto.Equal(transactions[2], history[1].GetTrx().Transaction))
+}
+
+func TestSubmitTransaction(t *testing.T) {
+	server := newTestRPCServer()
+	defer server.Close()
+	client := NewKoinosRPCClient(server.URL)
+	ctx := context.Background()
+
+	key, err := util.GenerateKoinosKey()
+	assert.NoError(t, err)
+
+	nonceBytes, err := util.UInt64ToNonceBytes(4)
+	assert.NoError(t, err)
+
+	server.respond(GetAccountNonceCall, &chain.GetAccountNonceResponse{Nonce: nonceBytes})
+	server.respond(GetAccountRcCall, &chain.GetAccountRcResponse{Rc: 1000})
+	server.respond(GetChainIDCall, &chain.GetChainIdResponse{ChainId: []byte{0x12, 0x20, 0x03}})
+	server.handle(SubmitTransactionCall, func(params json.RawMessage) (proto.Message, error) {
+		var req chain.SubmitTransactionRequest
+		unmarshalParams(t, params, &req)
+		assert.True(t, req.Broadcast)
+		assert.NoError(t, util.ValidateTransaction(req.Transaction))
+		assert.NoError(t, util.VerifyTransactionSignatures(req.Transaction, key.AddressBytes()))
+
+		nonce, err := util.NonceBytesToUInt64(req.Transaction.Header.Nonce)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(5), nonce)
+		assert.Equal(t, uint64(1000), req.Transaction.Header.RcLimit)
+
+		return &chain.SubmitTransactionResponse{Receipt: &protocol.TransactionReceipt{Id: req.Transaction.Id}}, nil
+	})
+
+	ops := []*protocol.Operation{{Op: &protocol.Operation_CallContract{CallContract: &protocol.CallContractOperation{ContractId: []byte{0x01}}}}}
+	receipt, err := client.SubmitTransaction(ctx, ops, key, nil, true)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, receipt.Id)
+
+	server.handle(SubmitTransactionCall, func(json.RawMessage) (proto.Message, error) {
+		return nil, assert.AnError
+	})
+	_, err = client.SubmitTransaction(ctx, ops, key, &SubmissionParams{Nonce: 1, RCLimit: 10}, true)
+	assert.EqualError(t, err, assert.AnError.Error())
+	assert.Equal(t, 1, server.callCount(GetAccountNonceCall))
+}
@@ -0,0 +1,189 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc/block_store"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc/chain"
+	transaction_store_rpc "github.com/koinos/koinos-proto-golang/v2/koinos/rpc/transaction_store"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/transaction_store"
+	util "github.com/koinos/koinos-util-golang/v2"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+// testChain is an in memory chain served through a test rpc server
+type testChain struct {
+	t   *testing.T
+	key *util.KoinosKey
+
+	mu           sync.Mutex
+	blocks       map[string]*protocol.Block
+	head         *protocol.Block
+	irreversible uint64
+	nonce        uint64
+}
+
+func newTestChain(t *testing.T, server *testRPCServer) *testChain {
+	key, err := util.GenerateKoinosKey()
+	assert.NoError(t, err)
+
+	c := &testChain{t: t, key: key, blocks: make(map[string]*protocol.Block)}
+	server.handle(GetHeadInfoCall, c.getHeadInfo)
+	server.handle(GetBlocksByHeightCall, c.getBlocksByHeight)
+	server.handle(GetBlocksByIDCall, c.getBlocksByID)
+	server.handle(GetTransactionsByIDCall, c.getTransactionsByID)
+	server.handle(GetAccountNonceCall, c.getAccountNonce)
+	return c
+}
+
+// extend produces num blocks on top of the given parent and makes the last one the head block.
+// The fork argument differentiates blocks of competing forks.
+func (c *testChain) extend(parent *protocol.Block, num int, fork uint64) []*protocol.Block {
+	blocks := make([]*protocol.Block, num)
+	for i := range blocks {
+		blocks[i] = c.produce(parent, nil, fork)
+		parent = blocks[i]
+	}
+
+	return blocks
+}
+
+// produce produces a block with the given transactions on top of the given parent and makes it the head block
+func (c *testChain) produce(parent *protocol.Block, transactions []*protocol.Transaction, fork uint64) *protocol.Block {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	header := &protocol.BlockHeader{Height: 1, Timestamp: fork}
+	if parent != nil {
+		header.Height = parent.Header.Height + 1
+		header.Previous = parent.Id
+	}
+
+	block, err := util.BuildBlock(header, transactions, c.key)
+	assert.NoError(c.t, err)
+
+	c.blocks[string(block.Id)] = block
+	c.head = block
+	return block
+}
+
+// setNonce sets the nonce returned for every account
+func (c *testChain) setNonce(nonce uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nonce = nonce
+}
+
+// setIrreversible sets the height of the last irreversible block
+func (c *testChain) setIrreversible(height uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.irreversible = height
+}
+
+func (c *testChain) getHeadInfo(json.RawMessage) (proto.Message, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resp := &chain.GetHeadInfoResponse{HeadTopology: &koinos.BlockTopology{}, LastIrreversibleBlock: c.irreversible}
+	if c.head != nil {
+		resp.HeadTopology = &koinos.BlockTopology{Id: c.head.Id, Height: c.head.Header.Height, Previous: c.head.Header.Previous}
+	}
+	return resp, nil
+}
+
+func (c *testChain) getBlocksByHeight(params json.RawMessage) (proto.Message, error) {
+	var req block_store.GetBlocksByHeightRequest
+	unmarshalParams(c.t, params, &req)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Collect the ancestors of the requested head in ascending order
+	var ancestors []*protocol.Block
+	for block := c.blocks[string(req.HeadBlockId)]; block != nil; block = c.blocks[string(block.Header.Previous)] {
+		ancestors = append([]*protocol.Block{block}, ancestors...)
+	}
+
+	resp := &block_store.GetBlocksByHeightResponse{}
+	for _, block := range ancestors {
+		if block.Header.Height >= req.AncestorStartHeight && uint32(len(resp.BlockItems)) < req.NumBlocks {
+			resp.BlockItems = append(resp.BlockItems, makeTestBlockItem(block, req.ReturnBlock, req.ReturnReceipt))
+		}
+	}
+	return resp, nil
+}
+
+func (c *testChain) getBlocksByID(params json.RawMessage) (proto.Message, error) {
+	var req block_store.GetBlocksByIdRequest
+	unmarshalParams(c.t, params, &req)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resp := &block_store.GetBlocksByIdResponse{}
+	for _, id := range req.BlockIds {
+		if block, ok := c.blocks[string(id)]; ok {
+			resp.BlockItems = append(resp.BlockItems, makeTestBlockItem(block, req.ReturnBlock, req.ReturnReceipt))
+		}
+	}
+	return resp, nil
+}
+
+func (c *testChain) getTransactionsByID(params json.RawMessage) (proto.Message, error) {
+	var req transaction_store_rpc.GetTransactionsByIdRequest
+	unmarshalParams(c.t, params, &req)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Index the transactions of all blocks, including those of abandoned forks
+	resp := &transaction_store_rpc.GetTransactionsByIdResponse{}
+	for _, id := range req.TransactionIds {
+		var item *transaction_store.TransactionItem
+		for _, block := range c.blocks {
+			for _, transaction := range block.Transactions {
+				if bytes.Equal(transaction.Id, id) {
+					if item == nil {
+						item = &transaction_store.TransactionItem{Transaction: transaction}
+					}
+					item.ContainingBlocks = append(item.ContainingBlocks, block.Id)
+				}
+			}
+		}
+		if item != nil {
+			resp.Transactions = append(resp.Transactions, item)
+		}
+	}
+	return resp, nil
+}
+
+func (c *testChain) getAccountNonce(json.RawMessage) (proto.Message, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	nonce, err := util.UInt64ToNonceBytes(c.nonce)
+	assert.NoError(c.t, err)
+	return &chain.GetAccountNonceResponse{Nonce: nonce}, nil
+}
+
+// makeTestBlockItem creates the block store item of a block, honoring the return options
+func makeTestBlockItem(block *protocol.Block, returnBlock bool, returnReceipt bool) *block_store.BlockItem {
+	item := &block_store.BlockItem{BlockId: block.Id, BlockHeight: block.Header.Height}
+	if returnBlock {
+		item.Block = block
+	}
+	if returnReceipt {
+		item.Receipt = &protocol.BlockReceipt{Id: block.Id, Height: block.Header.Height}
+		for _, transaction := range block.Transactions {
+			item.Receipt.TransactionReceipts = append(item.Receipt.TransactionReceipts, &protocol.TransactionReceipt{Id: transaction.Id, Payer: transaction.Header.Payer})
+		}
+	}
+	return item
+}
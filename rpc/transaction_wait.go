@@ -0,0 +1,210 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	util "github.com/koinos/koinos-util-golang/v2"
+)
+
+// DefaultTransactionPollInterval is the default interval between transaction confirmation polls
+const DefaultTransactionPollInterval = time.Second
+
+// TransactionWaitOptions is the options for waiting on a transaction
+type TransactionWaitOptions struct {
+	// Confirmations is the number of blocks that must be built on top of the block containing the transaction
+	Confirmations uint64
+
+	// Irreversible requires the block containing the transaction to be irreversible
+	Irreversible bool
+
+	// Timeout is the maximum time to wait. If zero, only the context deadline applies.
+	Timeout time.Duration
+
+	// PollInterval is the interval between polls. Defaults to DefaultTransactionPollInterval.
+	PollInterval time.Duration
+}
+
+// TransactionConfirmation is the inclusion of a transaction in a block
+type TransactionConfirmation struct {
+	BlockID       []byte
+	Height        uint64
+	Confirmations uint64
+	Receipt       *protocol.TransactionReceipt
+}
+
+// WaitForTransaction waits until the transaction is included in a block of the chain and has the
+// requested confirmations. ErrTransactionExpired is returned once the nonce of the transaction has
+// been used by another transaction, as it can then never be included.
+func (c *KoinosRPCClient) WaitForTransaction(ctx context.Context, transaction *protocol.Transaction, options *TransactionWaitOptions) (*TransactionConfirmation, error) {
+	if transaction.Header == nil {
+		return nil, util.ErrMissingHeader
+	}
+
+	nonce, err := util.NonceBytesToUInt64(transaction.Header.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	// The nonce belongs to the payee, or the payer when there is no payee
+	account := transaction.Header.Payee
+	if len(account) == 0 {
+		account = transaction.Header.Payer
+	}
+
+	expired := func(ctx context.Context) (bool, error) {
+		accountNonce, err := c.GetAccountNonce(ctx, account)
+		if err != nil {
+			return false, err
+		}
+
+		return accountNonce >= nonce, nil
+	}
+
+	return c.waitForTransaction(ctx, transaction.Id, expired, options)
+}
+
+// WaitForTransactionID waits until the transaction with the given ID is included in a block of the
+// chain and has the requested confirmations. As the transaction itself is not known, expiry can not
+// be detected and the wait only ends with a timeout.
+func (c *KoinosRPCClient) WaitForTransactionID(ctx context.Context, transactionID []byte, options *TransactionWaitOptions) (*TransactionConfirmation, error) {
+	return c.waitForTransaction(ctx, transactionID, nil, options)
+}
+
+func (c *KoinosRPCClient) waitForTransaction(ctx context.Context, transactionID []byte, expired func(context.Context) (bool, error), options *TransactionWaitOptions) (*TransactionConfirmation, error) {
+	opts := TransactionWaitOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	if opts.PollInterval == 0 {
+		opts.PollInterval = DefaultTransactionPollInterval
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	wasSpent := false
+	for {
+		// The nonce is checked before the transaction store so that a transaction included in between
+		// is found rather than reported as expired
+		spent := false
+		if expired != nil {
+			var err error
+			spent, err = expired(ctx)
+			if err != nil {
+				return nil, waitError(ctx, err)
+			}
+		}
+
+		confirmation, done, err := c.checkTransaction(ctx, transactionID, &opts)
+		if err != nil {
+			return nil, waitError(ctx, err)
+		}
+
+		if done {
+			return confirmation, nil
+		}
+
+		// The transaction store may lag behind the chain, so the nonce must be spent on two
+		// consecutive polls before the transaction is considered expired
+		if confirmation == nil && spent && wasSpent {
+			return nil, ErrTransactionExpired
+		}
+		wasSpent = confirmation == nil && spent
+
+		timer := time.NewTimer(opts.PollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, waitError(ctx, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// checkTransaction looks for the transaction on the current chain. It returns the confirmation if
+// the transaction is included and whether the confirmation satisfies the wait options.
+func (c *KoinosRPCClient) checkTransaction(ctx context.Context, transactionID []byte, opts *TransactionWaitOptions) (*TransactionConfirmation, bool, error) {
+	transactions, err := c.GetTransactionsByID(ctx, [][]byte{transactionID})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(transactions) == 0 || len(transactions[0].ContainingBlocks) == 0 {
+		return nil, false, nil
+	}
+
+	headInfo, err := c.GetHeadInfo(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if headInfo.HeadTopology == nil {
+		return nil, false, ErrInvalidHeadInfo
+	}
+
+	blocks, err := c.GetBlocksByID(ctx, transactions[0].ContainingBlocks, false, true)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The transaction may be included in blocks of several forks, find the one on the current chain
+	for _, block := range blocks {
+		if block.BlockHeight > headInfo.HeadTopology.Height {
+			continue
+		}
+
+		ancestors, err := c.GetBlocksByHeight(ctx, headInfo.HeadTopology.Id, block.BlockHeight, 1, false, false)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if len(ancestors) == 0 || !bytes.Equal(ancestors[0].BlockId, block.BlockId) {
+			continue
+		}
+
+		confirmation := &TransactionConfirmation{
+			BlockID:       block.BlockId,
+			Height:        block.BlockHeight,
+			Confirmations: headInfo.HeadTopology.Height - block.BlockHeight,
+		}
+
+		if block.Receipt != nil {
+			for _, receipt := range block.Receipt.TransactionReceipts {
+				if bytes.Equal(receipt.Id, transactionID) {
+					confirmation.Receipt = receipt
+					break
+				}
+			}
+		}
+
+		if confirmation.Receipt == nil {
+			return nil, false, ErrMissingReceipt
+		}
+
+		done := confirmation.Confirmations >= opts.Confirmations && (!opts.Irreversible || block.BlockHeight <= headInfo.LastIrreversibleBlock)
+		return confirmation, done, nil
+	}
+
+	return nil, false, nil
+}
+
+// waitError reports a timeout as ErrTransactionTimeout, as the rpc client does not wrap context errors
+func waitError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return ErrTransactionTimeout
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return err
+}
@@ -0,0 +1,120 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	chain_rpc "github.com/koinos/koinos-proto-golang/v2/koinos/rpc/chain"
+	util "github.com/koinos/koinos-util-golang/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeTestTransaction(t *testing.T, key *util.KoinosKey, nonce uint64) *protocol.Transaction {
+	builder := util.TransactionBuilder{ChainID: []byte{0x12, 0x20, 0x03}, Nonce: nonce, RCLimit: 100, Payer: key.AddressBytes()}
+	transaction, err := builder.BuildAndSign(key)
+	assert.NoError(t, err)
+	return transaction
+}
+
+func TestWaitForTransaction(t *testing.T) {
+	server := newTestRPCServer()
+	defer server.Close()
+	chain := newTestChain(t, server)
+	client := NewKoinosRPCClient(server.URL)
+	ctx := context.Background()
+
+	transaction := makeTestTransaction(t, chain.key, 1)
+	blocks := chain.extend(nil, 2, 0)
+	included := chain.produce(blocks[1], []*protocol.Transaction{transaction}, 0)
+	chain.extend(included, 1, 0)
+
+	confirmation, err := client.WaitForTransaction(ctx, transaction, &TransactionWaitOptions{Confirmations: 1, PollInterval: time.Millisecond})
+	assert.NoError(t, err)
+	assert.Equal(t, included.Id, confirmation.BlockID)
+	assert.Equal(t, uint64(3), confirmation.Height)
+	assert.Equal(t, uint64(1), confirmation.Confirmations)
+	assert.Equal(t, transaction.Id, confirmation.Receipt.Id)
+
+	// Not enough confirmations
+	options := &TransactionWaitOptions{Confirmations: 2, PollInterval: time.Millisecond, Timeout: 20 * time.Millisecond}
+	_, err = client.WaitForTransaction(ctx, transaction, options)
+	assert.ErrorIs(t, err, ErrTransactionTimeout)
+
+	chain.extend(chain.head, 1, 0)
+	confirmation, err = client.WaitForTransaction(ctx, transaction, options)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), confirmation.Confirmations)
+
+	// Not yet irreversible
+	options = &TransactionWaitOptions{Irreversible: true, PollInterval: time.Millisecond, Timeout: 20 * time.Millisecond}
+	chain.setIrreversible(2)
+	_, err = client.WaitForTransactionID(ctx, transaction.Id, options)
+	assert.ErrorIs(t, err, ErrTransactionTimeout)
+
+	chain.setIrreversible(3)
+	confirmation, err = client.WaitForTransactionID(ctx, transaction.Id, options)
+	assert.NoError(t, err)
+	assert.Equal(t, included.Id, confirmation.BlockID)
+}
+
+func TestWaitForTransactionFork(t *testing.T) {
+	server := newTestRPCServer()
+	defer server.Close()
+	chain := newTestChain(t, server)
+	client := NewKoinosRPCClient(server.URL)
+	ctx := context.Background()
+
+	transaction := makeTestTransaction(t, chain.key, 1)
+	blocks := chain.extend(nil, 2, 0)
+	chain.produce(blocks[1], []*protocol.Transaction{transaction}, 0)
+
+	// The head moves to a fork without the transaction
+	chain.extend(blocks[1], 2, 1)
+
+	options := &TransactionWaitOptions{PollInterval: time.Millisecond, Timeout: 20 * time.Millisecond}
+	_, err := client.WaitForTransaction(ctx, transaction, options)
+	assert.ErrorIs(t, err, ErrTransactionTimeout)
+
+	included := chain.produce(chain.head, []*protocol.Transaction{transaction}, 1)
+	confirmation, err := client.WaitForTransaction(ctx, transaction, options)
+	assert.NoError(t, err)
+	assert.Equal(t, included.Id, confirmation.BlockID)
+	assert.Equal(t, uint64(5), confirmation.Height)
+}
+
+func TestWaitForTransactionExpired(t *testing.T) {
+	server := newTestRPCServer()
+	defer server.Close()
+	chain := newTestChain(t, server)
+	client := NewKoinosRPCClient(server.URL)
+
+	transaction := makeTestTransaction(t, chain.key, 1)
+	chain.extend(nil, 2, 0)
+
+	// The nonce was used by another transaction
+	chain.setNonce(1)
+	_, err := client.WaitForTransaction(context.Background(), transaction, &TransactionWaitOptions{PollInterval: time.Millisecond})
+	assert.ErrorIs(t, err, ErrTransactionExpired)
+
+	// Expiry is not detected without the transaction
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	_, err = client.WaitForTransactionID(ctx, transaction.Id, &TransactionWaitOptions{PollInterval: time.Millisecond})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestWaitForTransactionInvalidHeadInfo(t *testing.T) {
+	server := newTestRPCServer()
+	defer server.Close()
+	chain := newTestChain(t, server)
+	client := NewKoinosRPCClient(server.URL)
+
+	transaction := makeTestTransaction(t, chain.key, 1)
+	chain.produce(nil, []*protocol.Transaction{transaction}, 0)
+	server.respond(GetHeadInfoCall, &chain_rpc.GetHeadInfoResponse{})
+
+	_, err := client.WaitForTransaction(context.Background(), transaction, &TransactionWaitOptions{PollInterval: time.Millisecond})
+	assert.ErrorIs(t, err, ErrInvalidHeadInfo)
+}
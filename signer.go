@@ -0,0 +1,14 @@
+package util
+
+// Signer signs digests on behalf of a Koinos address. It is implemented by KoinosKey
+// and may be implemented by remote signers that do not expose the private key.
+type Signer interface {
+	// Address returns the byte address of the signer
+	Address() []byte
+
+	// PublicKey returns the compressed public key of the signer
+	PublicKey() []byte
+
+	// SignDigest signs the given digest, returning a 65 byte compact signature
+	SignDigest(digest []byte) ([]byte, error)
+}
@@ -0,0 +1,61 @@
+package signer
+
+import (
+	"sync"
+
+	util "github.com/koinos/koinos-util-golang/v2"
+)
+
+// MemorySigner is an in-memory signer intended for tests. It records every digest it signs
+// and can be made to fail.
+type MemorySigner struct {
+	key *util.KoinosKey
+
+	mu      sync.Mutex
+	digests [][]byte
+	err     error
+}
+
+// NewMemorySigner creates a new memory signer for the given key
+func NewMemorySigner(key *util.KoinosKey) *MemorySigner {
+	return &MemorySigner{key: key}
+}
+
+// Address returns the byte address of the signer
+func (s *MemorySigner) Address() []byte {
+	return s.key.AddressBytes()
+}
+
+// PublicKey returns the compressed public key of the signer
+func (s *MemorySigner) PublicKey() []byte {
+	return s.key.PublicBytes()
+}
+
+// SignDigest signs the given digest, returning a compact signature
+func (s *MemorySigner) SignDigest(digest []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	s.digests = append(s.digests, append([]byte{}, digest...))
+	return s.key.SignDigest(digest)
+}
+
+// SetError makes all subsequent signing requests fail with the given error. A nil error restores signing.
+func (s *MemorySigner) SetError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.err = err
+}
+
+// Digests returns the digests signed so far
+func (s *MemorySigner) Digests() [][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([][]byte{}, s.digests...)
+}
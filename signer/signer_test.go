@@ -0,0 +1,84 @@
+package signer
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	util "github.com/koinos/koinos-util-golang/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeTestTransaction(t *testing.T, signer util.Signer) *protocol.Transaction {
+	builder := util.TransactionBuilder{
+		Operations: []*protocol.Operation{{Op: &protocol.Operation_CallContract{CallContract: &protocol.CallContractOperation{ContractId: []byte{0x01}}}}},
+		ChainID:    []byte{0x12, 0x20, 0x01},
+		Nonce:      1,
+		RCLimit:    100,
+		Payer:      signer.Address(),
+	}
+
+	transaction, err := builder.BuildAndSign(signer)
+	assert.NoError(t, err)
+
+	return transaction
+}
+
+func TestMemorySigner(t *testing.T) {
+	key, err := util.GenerateKoinosKey()
+	assert.NoError(t, err)
+
+	signer := NewMemorySigner(key)
+	assert.Equal(t, key.AddressBytes(), signer.Address())
+	assert.Equal(t, key.PublicBytes(), signer.PublicKey())
+
+	transaction := makeTestTransaction(t, signer)
+	assert.NoError(t, util.VerifyTransactionSignatures(transaction, key.AddressBytes()))
+	assert.Len(t, signer.Digests(), 1)
+
+	signErr := errors.New("signer unavailable")
+	signer.SetError(signErr)
+	assert.ErrorIs(t, util.SignTransactionWithSigner(signer, transaction), signErr)
+	assert.Len(t, transaction.Signatures, 1)
+}
+
+func TestSocketSigner(t *testing.T) {
+	dir, err := ioutil.TempDir("", "koinos-signer")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	key, err := util.GenerateKoinosKey()
+	assert.NoError(t, err)
+
+	path := filepath.Join(dir, "signer.sock")
+	memorySigner := NewMemorySigner(key)
+	server, err := NewSocketSignerServer(path, memorySigner)
+	assert.NoError(t, err)
+	go server.Serve()
+	defer server.Close()
+
+	signer, err := NewSocketSigner(path)
+	assert.NoError(t, err)
+	assert.Equal(t, key.AddressBytes(), signer.Address())
+	assert.Equal(t, key.PublicBytes(), signer.PublicKey())
+
+	transaction := makeTestTransaction(t, signer)
+	assert.NoError(t, util.VerifyTransactionSignatures(transaction, key.AddressBytes()))
+	assert.Len(t, memorySigner.Digests(), 1)
+
+	memorySigner.SetError(errors.New("signer unavailable"))
+	_, err = signer.SignDigest(make([]byte, 32))
+	assert.EqualError(t, err, "signer unavailable")
+
+	// The server is queried when the signer is created
+	_, err = NewSocketSigner(filepath.Join(dir, "missing.sock"))
+	assert.Error(t, err)
+
+	server.Close()
+	_, err = signer.SignDigest(make([]byte, 32))
+	assert.Error(t, err)
+	assert.Equal(t, key.AddressBytes(), signer.Address())
+}
@@ -0,0 +1,175 @@
+package signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	util "github.com/koinos/koinos-util-golang/v2"
+)
+
+// These are the methods served by a socket signer server
+const (
+	AddressMethod    = "address"
+	PublicKeyMethod  = "public_key"
+	SignDigestMethod = "sign_digest"
+)
+
+// socketRequest is a request sent to a socket signer server
+type socketRequest struct {
+	Method string `json:"method"`
+	Digest []byte `json:"digest,omitempty"`
+}
+
+// socketResponse is a response sent by a socket signer server
+type socketResponse struct {
+	Result []byte `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// SocketSigner is a signer that forwards requests to a signer server listening on a Unix socket.
+// It stands in for remote signers in tests and local tooling.
+type SocketSigner struct {
+	path      string
+	address   []byte
+	publicKey []byte
+}
+
+// NewSocketSigner creates a new socket signer connecting to the given socket path. The server is
+// queried once for the address and public key, so that an unreachable server is reported here
+// rather than surfacing as an empty address later.
+func NewSocketSigner(path string) (*SocketSigner, error) {
+	s := &SocketSigner{path: path}
+
+	var err error
+	s.publicKey, err = s.call(&socketRequest{Method: PublicKeyMethod})
+	if err != nil {
+		return nil, err
+	}
+
+	s.address, err = s.call(&socketRequest{Method: AddressMethod})
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := util.AddressFromPublicKey(s.publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(address, s.address) {
+		return nil, fmt.Errorf("%w: address does not match public key", util.ErrInvalidAddress)
+	}
+
+	return s, nil
+}
+
+// Address returns the byte address of the signer
+func (s *SocketSigner) Address() []byte {
+	return s.address
+}
+
+// PublicKey returns the compressed public key of the signer
+func (s *SocketSigner) PublicKey() []byte {
+	return s.publicKey
+}
+
+// SignDigest signs the given digest, returning a compact signature
+func (s *SocketSigner) SignDigest(digest []byte) ([]byte, error) {
+	return s.call(&socketRequest{Method: SignDigestMethod, Digest: digest})
+}
+
+// call sends a single request to the server and waits for the response
+func (s *SocketSigner) call(req *socketRequest) ([]byte, error) {
+	conn, err := net.Dial("unix", s.path)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, err
+	}
+
+	var resp socketResponse
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+
+	return resp.Result, nil
+}
+
+// SocketSignerServer serves a signer over a Unix socket
+type SocketSignerServer struct {
+	listener net.Listener
+	signer   util.Signer
+	wg       sync.WaitGroup
+}
+
+// NewSocketSignerServer creates a new server for the given signer, listening on the given socket path
+func NewSocketSignerServer(path string, signer util.Signer) (*SocketSignerServer, error) {
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SocketSignerServer{listener: listener, signer: signer}, nil
+}
+
+// Serve accepts connections until the server is closed
+func (s *SocketSignerServer) Serve() error {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return err
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+			s.handle(conn)
+		}()
+	}
+}
+
+// Close stops the server and waits for open connections to be handled
+func (s *SocketSignerServer) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+// handle serves a single request
+func (s *SocketSignerServer) handle(conn net.Conn) {
+	var req socketRequest
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		return
+	}
+
+	var resp socketResponse
+	switch req.Method {
+	case AddressMethod:
+		resp.Result = s.signer.Address()
+	case PublicKeyMethod:
+		resp.Result = s.signer.PublicKey()
+	case SignDigestMethod:
+		signature, err := s.signer.SignDigest(req.Digest)
+		if err != nil {
+			resp.Error = err.Error()
+		} else {
+			resp.Result = signature
+		}
+	default:
+		resp.Error = "unknown method " + req.Method
+	}
+
+	json.NewEncoder(conn).Encode(&resp)
+}
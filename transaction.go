@@ -0,0 +1,206 @@
+package util
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/canonical"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	"github.com/multiformats/go-multihash"
+)
+
+// TransactionBuilder assembles a transaction from explicitly provided parameters, without any network access
+type TransactionBuilder struct {
+	Operations []*protocol.Operation
+	ChainID    []byte
+	Nonce      uint64
+	RCLimit    uint64
+	Payer      []byte
+	Payee      []byte
+}
+
+// NewTransactionBuilder creates a new transaction builder for the given chain
+func NewTransactionBuilder(chainID []byte) *TransactionBuilder {
+	return &TransactionBuilder{ChainID: chainID}
+}
+
+// AddOperation appends an operation to the transaction
+func (b *TransactionBuilder) AddOperation(op *protocol.Operation) *TransactionBuilder {
+	b.Operations = append(b.Operations, op)
+	return b
+}
+
+// Header creates the transaction header, including the operation merkle root
+func (b *TransactionBuilder) Header() (*protocol.TransactionHeader, error) {
+	if len(b.ChainID) == 0 {
+		return nil, ErrMissingChainID
+	}
+
+	// Convert nonce to bytes
+	nonceBytes, err := UInt64ToNonceBytes(b.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	merkleRoot, err := CalculateOperationMerkleRoot(b.Operations)
+	if err != nil {
+		return nil, err
+	}
+
+	header := &protocol.TransactionHeader{
+		ChainId:             b.ChainID,
+		RcLimit:             b.RCLimit,
+		Nonce:               nonceBytes,
+		OperationMerkleRoot: merkleRoot,
+		Payer:               b.Payer,
+	}
+
+	if len(b.Payee) > 0 {
+		header.Payee = b.Payee
+	}
+
+	return header, nil
+}
+
+// Build creates the unsigned transaction and calculates its ID
+func (b *TransactionBuilder) Build() (*protocol.Transaction, error) {
+	header, err := b.Header()
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := CalculateTransactionID(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return &protocol.Transaction{Header: header, Operations: b.Operations, Id: id}, nil
+}
+
+// BuildAndSign creates the transaction and signs it with the given signer
+func (b *TransactionBuilder) BuildAndSign(signer Signer) (*protocol.Transaction, error) {
+	transaction, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := SignTransactionWithSigner(signer, transaction); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// CalculateOperationMerkleRoot calculates the merkle root of the given operations
+func CalculateOperationMerkleRoot(ops []*protocol.Operation) ([]byte, error) {
+	// Get operation multihashes
+	opHashes := make([][]byte, len(ops))
+	for i, op := range ops {
+		var err error
+		opHashes[i], err = HashMessage(op)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return CalculateMerkleRoot(opHashes)
+}
+
+// CalculateTransactionID calculates the multihash ID of the given transaction header
+func CalculateTransactionID(header *protocol.TransactionHeader) ([]byte, error) {
+	headerBytes, err := canonical.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+
+	hasher := sha256.New()
+	hasher.Write(headerBytes)
+	return multihash.Encode(hasher.Sum(nil), multihash.SHA2_256)
+}
+
+// RecoverTransactionSigners recovers the addresses of all signers of the given transaction
+func RecoverTransactionSigners(tx *protocol.Transaction) ([][]byte, error) {
+	if tx.Header == nil {
+		return nil, ErrMissingHeader
+	}
+
+	// Recompute the ID so that signatures are checked against the actual header contents
+	id, err := CalculateTransactionID(tx.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(id, tx.Id) {
+		return nil, ErrTransactionIDMismatch
+	}
+
+	idBytes, err := multihash.Decode(id)
+	if err != nil {
+		return nil, err
+	}
+
+	signers := make([][]byte, len(tx.Signatures))
+	for i, signature := range tx.Signatures {
+		signers[i], err = RecoverAddress(idBytes.Digest, signature)
+		if err != nil {
+			return nil, fmt.Errorf("signature %d: %w", i, err)
+		}
+	}
+
+	return signers, nil
+}
+
+// VerifyTransactionSignatures verifies that the given transaction is signed by all of the given addresses
+func VerifyTransactionSignatures(tx *protocol.Transaction, addresses ...[]byte) error {
+	missing, err := MissingTransactionSigners(tx, addresses...)
+	if err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrMissingSignature, DisplayAddress(missing[0]))
+	}
+
+	return nil
+}
+
+// ValidateTransaction checks that the transaction header is well formed and that the operation
+// merkle root and transaction ID match the transaction contents
+func ValidateTransaction(tx *protocol.Transaction) error {
+	if tx.Header == nil {
+		return ErrMissingHeader
+	}
+
+	if len(tx.Header.ChainId) == 0 {
+		return ErrMissingChainID
+	}
+
+	if _, err := NonceBytesToUInt64(tx.Header.Nonce); err != nil {
+		if errors.Is(err, ErrInvalidNonce) {
+			return err
+		}
+		return fmt.Errorf("%w: %s", ErrInvalidNonce, err)
+	}
+
+	merkleRoot, err := CalculateOperationMerkleRoot(tx.Operations)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(merkleRoot, tx.Header.OperationMerkleRoot) {
+		return ErrOperationMerkleRootMismatch
+	}
+
+	id, err := CalculateTransactionID(tx.Header)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(id, tx.Id) {
+		return ErrTransactionIDMismatch
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package util
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeTestOperations() []*protocol.Operation {
+	return []*protocol.Operation{
+		{Op: &protocol.Operation_CallContract{CallContract: &protocol.CallContractOperation{ContractId: []byte{0x01}, EntryPoint: 1, Args: []byte{0x02}}}},
+		{Op: &protocol.Operation_CallContract{CallContract: &protocol.CallContractOperation{ContractId: []byte{0x03}, EntryPoint: 2, Args: []byte{0x04}}}},
+	}
+}
+
+func makeTestChainID() []byte {
+	hasher := sha256.New()
+	hasher.Write([]byte("chain"))
+	mh, _ := multihash.Encode(hasher.Sum(nil), multihash.SHA2_256)
+	return mh
+}
+
+func TestTransactionBuilder(t *testing.T) {
+	key, err := GenerateKoinosKey()
+	assert.NoError(t, err)
+
+	ops := makeTestOperations()
+	builder := TransactionBuilder{Operations: ops, ChainID: makeTestChainID(), Nonce: 5, RCLimit: 100, Payer: key.AddressBytes()}
+
+	transaction, err := builder.Build()
+	assert.NoError(t, err)
+	assert.Empty(t, transaction.Signatures)
+	assert.Nil(t, transaction.Header.Payee)
+
+	nonce, err := NonceBytesToUInt64(transaction.Header.Nonce)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), nonce)
+
+	opHashes := make([][]byte, len(ops))
+	for i, op := range ops {
+		opHashes[i], err = HashMessage(op)
+		assert.NoError(t, err)
+	}
+	merkleRoot, err := CalculateMerkleRoot(opHashes)
+	assert.NoError(t, err)
+	assert.Equal(t, merkleRoot, transaction.Header.OperationMerkleRoot)
+
+	id, err := HashMessage(transaction.Header)
+	assert.NoError(t, err)
+	assert.Equal(t, id, transaction.Id)
+
+	signed, err := builder.BuildAndSign(key)
+	assert.NoError(t, err)
+	assert.Equal(t, transaction.Id, signed.Id)
+	assert.Len(t, signed.Signatures, 1)
+
+	builder.ChainID = nil
+	_, err = builder.Build()
+	assert.ErrorIs(t, err, ErrMissingChainID)
+}
+
+func TestTransactionSignatures(t *testing.T) {
+	key1, err := GenerateKoinosKey()
+	assert.NoError(t, err)
+	key2, err := GenerateKoinosKey()
+	assert.NoError(t, err)
+	key3, err := GenerateKoinosKey()
+	assert.NoError(t, err)
+
+	builder := TransactionBuilder{Operations: makeTestOperations(), ChainID: makeTestChainID(), Nonce: 1, RCLimit: 100, Payer: key1.AddressBytes()}
+	transaction, err := builder.BuildAndSign(key1)
+	assert.NoError(t, err)
+	assert.NoError(t, SignTransaction(key2.PrivateBytes(), transaction))
+
+	signers, err := RecoverTransactionSigners(transaction)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{key1.AddressBytes(), key2.AddressBytes()}, signers)
+
+	assert.NoError(t, VerifyTransactionSignatures(transaction, key1.AddressBytes(), key2.AddressBytes()))
+	assert.ErrorIs(t, VerifyTransactionSignatures(transaction, key3.AddressBytes()), ErrMissingSignature)
+
+	// Invalid recovery flag
+	transaction.Signatures = append(transaction.Signatures, make([]byte, 65))
+	_, err = RecoverTransactionSigners(transaction)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+	transaction.Signatures = transaction.Signatures[:2]
+
+	// Modifying the header invalidates the ID
+	transaction.Header.RcLimit++
+	_, err = RecoverTransactionSigners(transaction)
+	assert.ErrorIs(t, err, ErrTransactionIDMismatch)
+}
+
+func TestValidateTransaction(t *testing.T) {
+	builder := TransactionBuilder{Operations: makeTestOperations(), ChainID: makeTestChainID(), Nonce: 1, RCLimit: 100, Payer: []byte{0x00}}
+	transaction, err := builder.Build()
+	assert.NoError(t, err)
+	assert.NoError(t, ValidateTransaction(transaction))
+
+	assert.ErrorIs(t, ValidateTransaction(&protocol.Transaction{}), ErrMissingHeader)
+
+	// Operations no longer match the merkle root
+	transaction.Operations = transaction.Operations[:1]
+	assert.ErrorIs(t, ValidateTransaction(transaction), ErrOperationMerkleRootMismatch)
+
+	transaction, err = builder.Build()
+	assert.NoError(t, err)
+	transaction.Id = makeTestChainID()
+	assert.ErrorIs(t, ValidateTransaction(transaction), ErrTransactionIDMismatch)
+
+	transaction, err = builder.Build()
+	assert.NoError(t, err)
+	transaction.Header.ChainId = nil
+	assert.ErrorIs(t, ValidateTransaction(transaction), ErrMissingChainID)
+
+	transaction, err = builder.Build()
+	assert.NoError(t, err)
+	transaction.Header.Nonce = []byte{0xff}
+	assert.ErrorIs(t, ValidateTransaction(transaction), ErrInvalidNonce)
+
+	transaction.Header.Nonce = nil
+	assert.ErrorIs(t, ValidateTransaction(transaction), ErrInvalidNonce)
+}
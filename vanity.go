@@ -0,0 +1,317 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	mathrand "math/rand"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// defaultVanityProgressInterval is the default interval between progress reports
+const defaultVanityProgressInterval = time.Second
+
+// MaxVanityDifficulty is the largest expected number of attempts accepted for a vanity prefix.
+// Prefixes beyond it can practically never be found.
+const MaxVanityDifficulty float64 = 1 << 64
+
+// VanityOptions configures the search for a vanity address
+type VanityOptions struct {
+	// Prefix the base58 address must start with, including the leading 1
+	Prefix string
+
+	// Pattern the base58 address must match, in addition to the prefix
+	Pattern *regexp.Regexp
+
+	// Workers is the number of goroutines searching concurrently. Defaults to runtime.NumCPU().
+	Workers int
+
+	// Progress, if set, is called periodically with the progress of the search
+	Progress func(VanityProgress)
+
+	// ProgressInterval is the interval between progress reports. Defaults to one second.
+	ProgressInterval time.Duration
+}
+
+// VanityProgress reports the progress of a vanity address search
+type VanityProgress struct {
+	Attempts       uint64
+	Elapsed        time.Duration
+	AttemptsPerSec float64
+}
+
+// VanityResult is a key whose address matches the requested vanity options
+type VanityResult struct {
+	Key      *KoinosKey
+	Address  string
+	Attempts uint64
+}
+
+// GenerateVanityKey searches for a key whose base58 address matches the given options.
+// The search runs until a match is found or the context is cancelled.
+func GenerateVanityKey(ctx context.Context, opts VanityOptions) (*VanityResult, error) {
+	if err := validateVanityPrefix(opts.Prefix); err != nil {
+		return nil, err
+	}
+
+	if opts.Prefix == "" && opts.Pattern == nil {
+		return nil, fmt.Errorf("%w: a prefix or pattern is required", ErrInvalidVanityPattern)
+	}
+
+	workers := opts.Workers
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	interval := opts.ProgressInterval
+	if interval <= 0 {
+		interval = defaultVanityProgressInterval
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var attempts uint64
+	var once sync.Once
+	var result *VanityResult
+	var resultErr error
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for ctx.Err() == nil {
+				key, err := GenerateKoinosKey()
+				if err != nil {
+					once.Do(func() { resultErr = err })
+					cancel()
+					return
+				}
+
+				n := atomic.AddUint64(&attempts, 1)
+				address := base58.Encode(key.AddressBytes())
+				if !strings.HasPrefix(address, opts.Prefix) || (opts.Pattern != nil && !opts.Pattern.MatchString(address)) {
+					continue
+				}
+
+				once.Do(func() { result = &VanityResult{Key: key, Address: address, Attempts: n} })
+				cancel()
+				return
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	start := time.Now()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			if resultErr != nil {
+				return nil, resultErr
+			}
+			if result != nil {
+				return result, nil
+			}
+			return nil, ctx.Err()
+		case <-ticker.C:
+			if opts.Progress != nil {
+				elapsed := time.Since(start)
+				n := atomic.LoadUint64(&attempts)
+				opts.Progress(VanityProgress{Attempts: n, Elapsed: elapsed, AttemptsPerSec: float64(n) / elapsed.Seconds()})
+			}
+		}
+	}
+}
+
+// VanityDifficulty calculates the expected number of attempts to find an address with the given base58 prefix.
+// The 24 bytes following the version byte are assumed to be uniformly distributed, so the difficulty accounts
+// for the uneven distribution of the leading base58 characters.
+func VanityDifficulty(prefix string) (float64, error) {
+	if err := validateVanityPrefix(prefix); err != nil {
+		return 0, err
+	}
+
+	return vanityPrefixDifficulty(prefix), nil
+}
+
+// VanityPatternDifficulty estimates the expected number of attempts to find an address with the given base58
+// prefix that also matches the given pattern. The probability of the prefix is calculated exactly, while the
+// probability of the pattern is estimated from the given number of random addresses with that prefix.
+// If no sampled address matches the pattern, the difficulty is +Inf.
+func VanityPatternDifficulty(prefix string, pattern *regexp.Regexp, samples int) (float64, error) {
+	if err := validateVanityPrefix(prefix); err != nil {
+		return 0, err
+	}
+
+	if pattern == nil {
+		return vanityPrefixDifficulty(prefix), nil
+	}
+
+	if samples < 1 {
+		return 0, fmt.Errorf("%w: at least one sample is required", ErrInvalidVanityPattern)
+	}
+
+	ranges := vanityPrefixRanges(prefix)
+	total := new(big.Int)
+	for _, r := range ranges {
+		total.Add(total, r.size())
+	}
+
+	rng := mathrand.New(mathrand.NewSource(time.Now().UnixNano()))
+	address := make([]byte, vanityValueLen+1)
+	matches := 0
+
+	for i := 0; i < samples; i++ {
+		// Pick a value uniformly from the union of the ranges
+		offset := new(big.Int).Rand(rng, total)
+		for _, r := range ranges {
+			if offset.Cmp(r.size()) < 0 {
+				offset.Add(offset, r.low)
+				break
+			}
+			offset.Sub(offset, r.size())
+		}
+
+		offset.FillBytes(address[1:])
+		if pattern.MatchString(base58.Encode(address)) {
+			matches++
+		}
+	}
+
+	if matches == 0 {
+		return math.Inf(1), nil
+	}
+
+	return vanityPrefixDifficulty(prefix) * float64(samples) / float64(matches), nil
+}
+
+// vanityValueLen is the number of bytes following the version byte of an address
+const vanityValueLen = AddressLength - 1
+
+// vanityRange is a half open range of values following the version byte of an address
+type vanityRange struct {
+	low  *big.Int
+	high *big.Int
+}
+
+func (r vanityRange) size() *big.Int {
+	return new(big.Int).Sub(r.high, r.low)
+}
+
+// vanityPrefixRanges returns the ranges of values following the version byte whose address starts with the
+// given prefix. The prefix must begin with the 1 encoding the version byte.
+func vanityPrefixRanges(prefix string) []vanityRange {
+	// Each further leading 1 encodes a zero byte, the remaining characters encode the value without leading zeros
+	rest := strings.TrimPrefix(prefix, "1")
+	zeros := len(rest) - len(strings.TrimLeft(rest, "1"))
+	digits := rest[zeros:]
+
+	if zeros > vanityValueLen || (zeros == vanityValueLen && digits != "") {
+		return nil
+	}
+
+	high := new(big.Int).Lsh(big.NewInt(1), uint(8*(vanityValueLen-zeros)))
+	if digits == "" {
+		return []vanityRange{{low: new(big.Int), high: high}}
+	}
+
+	// The value must have exactly the requested number of leading zero bytes
+	low := new(big.Int).Lsh(big.NewInt(1), uint(8*(vanityValueLen-zeros-1)))
+
+	value := new(big.Int)
+	for _, c := range digits {
+		value.Mul(value, big.NewInt(58))
+		value.Add(value, big.NewInt(int64(strings.IndexRune(base58Alphabet, c))))
+	}
+
+	// Values whose base58 encoding starts with the digits, for every encoded length
+	var ranges []vanityRange
+	start := value
+	end := new(big.Int).Add(value, big.NewInt(1))
+	for start.Cmp(high) < 0 {
+		r := vanityRange{low: maxBig(start, low), high: minBig(end, high)}
+		if r.low.Cmp(r.high) < 0 {
+			ranges = append(ranges, r)
+		}
+
+		start = new(big.Int).Mul(start, big.NewInt(58))
+		end = new(big.Int).Mul(end, big.NewInt(58))
+	}
+
+	return ranges
+}
+
+// vanityPrefixDifficulty calculates the expected number of attempts to find an address with the given prefix
+func vanityPrefixDifficulty(prefix string) float64 {
+	if prefix == "" {
+		return 1
+	}
+
+	count := new(big.Int)
+	for _, r := range vanityPrefixRanges(prefix) {
+		count.Add(count, r.size())
+	}
+
+	if count.Sign() == 0 {
+		return math.Inf(1)
+	}
+
+	space := new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), 8*vanityValueLen))
+	difficulty, _ := new(big.Float).Quo(space, new(big.Float).SetInt(count)).Float64()
+	return difficulty
+}
+
+func maxBig(a *big.Int, b *big.Int) *big.Int {
+	if a.Cmp(b) > 0 {
+		return a
+	}
+	return b
+}
+
+func minBig(a *big.Int, b *big.Int) *big.Int {
+	if a.Cmp(b) < 0 {
+		return a
+	}
+	return b
+}
+
+// validateVanityPrefix checks that the prefix can occur in a Koinos address within MaxVanityDifficulty attempts
+func validateVanityPrefix(prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+
+	if prefix[0] != '1' {
+		return fmt.Errorf("%w: addresses begin with 1", ErrInvalidVanityPattern)
+	}
+
+	for _, c := range prefix {
+		if !strings.ContainsRune(base58Alphabet, c) {
+			return fmt.Errorf("%w: %q is not a base58 character", ErrInvalidVanityPattern, c)
+		}
+	}
+
+	if difficulty := vanityPrefixDifficulty(prefix); difficulty > MaxVanityDifficulty {
+		return fmt.Errorf("%w: %s is expected to take %g attempts", ErrInvalidVanityPattern, prefix, difficulty)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package util
+
+import (
+	"context"
+	"crypto/rand"
+	"math"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVanityKey(t *testing.T) {
+	result, err := GenerateVanityKey(context.Background(), VanityOptions{Prefix: "1K", Workers: 2})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(result.Address, "1K"))
+	assert.Equal(t, result.Address, base58.Encode(result.Key.AddressBytes()))
+	assert.True(t, result.Attempts > 0)
+
+	result, err = GenerateVanityKey(context.Background(), VanityOptions{Pattern: regexp.MustCompile("[kK]$")})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(strings.ToLower(result.Address), "k"))
+
+	_, err = GenerateVanityKey(context.Background(), VanityOptions{Prefix: "2"})
+	assert.ErrorIs(t, err, ErrInvalidVanityPattern)
+
+	_, err = GenerateVanityKey(context.Background(), VanityOptions{Prefix: "1O"})
+	assert.ErrorIs(t, err, ErrInvalidVanityPattern)
+
+	_, err = GenerateVanityKey(context.Background(), VanityOptions{})
+	assert.ErrorIs(t, err, ErrInvalidVanityPattern)
+}
+
+func TestVanityKeyCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	var reports int32
+	_, err := GenerateVanityKey(ctx, VanityOptions{
+		Prefix:           "1zzzzzzzzzz",
+		ProgressInterval: 20 * time.Millisecond,
+		Progress: func(p VanityProgress) {
+			atomic.AddInt32(&reports, 1)
+		},
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, atomic.LoadInt32(&reports) > 0)
+}
+
+func TestVanityDifficulty(t *testing.T) {
+	d, err := VanityDifficulty("1")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), d)
+
+	// Each further leading 1 is a zero byte
+	d, err = VanityDifficulty("111")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1<<16), d)
+
+	_, err = VanityDifficulty("1l")
+	assert.ErrorIs(t, err, ErrInvalidVanityPattern)
+
+	// The second character is far from uniform, compare against sampled addresses
+	counts := make(map[string]int)
+	address := make([]byte, AddressLength)
+	samples := 20000
+	for i := 0; i < samples; i++ {
+		_, err := rand.Read(address[1:])
+		assert.NoError(t, err)
+		counts[base58.Encode(address)[:2]]++
+	}
+
+	for _, prefix := range []string{"1K", "12", "1Q"} {
+		d, err = VanityDifficulty(prefix)
+		assert.NoError(t, err)
+		assert.InEpsilon(t, float64(samples)/float64(counts[prefix]), d, 0.2, prefix)
+	}
+
+	d, err = VanityDifficulty("1z")
+	assert.NoError(t, err)
+	assert.InEpsilon(t, 1353.6, d, 0.001)
+
+	// Prefixes that can never, or practically never, occur
+	_, err = VanityDifficulty("1" + strings.Repeat("z", 33))
+	assert.ErrorIs(t, err, ErrInvalidVanityPattern)
+
+	_, err = VanityDifficulty(strings.Repeat("1", 27))
+	assert.ErrorIs(t, err, ErrInvalidVanityPattern)
+
+	_, err = VanityDifficulty("1zzzzzzzzzzzz")
+	assert.ErrorIs(t, err, ErrInvalidVanityPattern)
+
+	_, err = GenerateVanityKey(context.Background(), VanityOptions{Prefix: "1zzzzzzzzzzzz"})
+	assert.ErrorIs(t, err, ErrInvalidVanityPattern)
+}
+
+func TestVanityPatternDifficulty(t *testing.T) {
+	d, err := VanityPatternDifficulty("1K", nil, 0)
+	assert.NoError(t, err)
+	prefixDifficulty, err := VanityDifficulty("1K")
+	assert.NoError(t, err)
+	assert.Equal(t, prefixDifficulty, d)
+
+	// A final character is close to uniform
+	d, err = VanityPatternDifficulty("1K", regexp.MustCompile("[kK]$"), 20000)
+	assert.NoError(t, err)
+	assert.InEpsilon(t, prefixDifficulty*29, d, 0.2)
+
+	// Anchored patterns follow the prefix distribution
+	d, err = VanityPatternDifficulty("", regexp.MustCompile("^1Q"), 20000)
+	assert.NoError(t, err)
+	prefixDifficulty, err = VanityDifficulty("1Q")
+	assert.NoError(t, err)
+	assert.InEpsilon(t, prefixDifficulty, d, 0.2)
+
+	d, err = VanityPatternDifficulty("1K", regexp.MustCompile("^1z"), 1000)
+	assert.NoError(t, err)
+	assert.True(t, math.IsInf(d, 1))
+
+	_, err = VanityPatternDifficulty("1K", regexp.MustCompile("k$"), 0)
+	assert.ErrorIs(t, err, ErrInvalidVanityPattern)
+}